@@ -0,0 +1,58 @@
+// Package client provides composable building blocks for constructing and
+// submitting signed Ethereum transactions against the gateway, used both by
+// the gateway's own integration tests and by external callers.
+package client
+
+import (
+	"context"
+	"math/big"
+	"sync"
+)
+
+// ChainReader is the subset of ethclient.Client that ChainIDProvider needs.
+type ChainReader interface {
+	ChainID(ctx context.Context) (*big.Int, error)
+}
+
+// ChainIDProvider resolves a chain id, caching it after the first lookup.
+// A fixed override can be supplied for tests that already know the chain id
+// and want to avoid the round trip.
+type ChainIDProvider struct {
+	reader ChainReader
+	fixed  *big.Int
+
+	mu      sync.Mutex
+	chainID *big.Int
+}
+
+// NewChainIDProvider creates a provider that looks up and caches the chain
+// id from the given reader.
+func NewChainIDProvider(reader ChainReader) *ChainIDProvider {
+	return &ChainIDProvider{reader: reader}
+}
+
+// NewFixedChainIDProvider creates a provider that always returns the given
+// chain id without making any network calls.
+func NewFixedChainIDProvider(chainID *big.Int) *ChainIDProvider {
+	return &ChainIDProvider{fixed: chainID}
+}
+
+// ChainID returns the chain id, resolving and caching it on first use.
+func (p *ChainIDProvider) ChainID(ctx context.Context) (*big.Int, error) {
+	if p.fixed != nil {
+		return p.fixed, nil
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.chainID != nil {
+		return p.chainID, nil
+	}
+
+	chainID, err := p.reader.ChainID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	p.chainID = chainID
+	return chainID, nil
+}
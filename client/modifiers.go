@@ -0,0 +1,134 @@
+package client
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// TxRequest describes a transaction's intent before it has been fully
+// populated and signed. Callers fill in `To`, `Value`, and `Data`; the
+// remaining fields are left for the modifier pipeline to fill in.
+type TxRequest struct {
+	To    *common.Address
+	Value *big.Int
+	Data  []byte
+
+	Nonce     uint64
+	Gas       uint64
+	GasPrice  *big.Int // set for a legacy transaction
+	GasTipCap *big.Int // set together with GasFeeCap for a dynamic-fee transaction
+	GasFeeCap *big.Int
+	ChainID   *big.Int
+
+	signed *types.Transaction
+}
+
+// TxModifier fills in or adjusts one aspect of a TxRequest before it is
+// submitted. Modifiers are applied in order, each able to rely on fields
+// set by earlier ones (e.g. SignerModifier relies on ChainIDModifier having
+// already run).
+type TxModifier func(ctx context.Context, req *TxRequest) error
+
+// NonceAccountReader is the subset of ethclient.Client that NonceProvider needs.
+type NonceAccountReader interface {
+	NonceAt(ctx context.Context, account common.Address, blockNumber *big.Int) (uint64, error)
+}
+
+// NonceProvider sets the transaction nonce to the account's nonce at the
+// latest block; transactions still pending in the pool are not counted.
+func NonceProvider(reader NonceAccountReader, account common.Address) TxModifier {
+	return func(ctx context.Context, req *TxRequest) error {
+		nonce, err := reader.NonceAt(ctx, account, nil)
+		if err != nil {
+			return err
+		}
+		req.Nonce = nonce
+		return nil
+	}
+}
+
+// GasLimitEstimator sets a fixed gas limit on the transaction. The
+// gateway's own tests declare gas limits explicitly rather than estimating
+// them, since Oasis runtime gas accounting does not always match
+// go-ethereum's estimator; this modifier is the composable extension point
+// for callers that do want a fixed or pre-computed limit.
+func GasLimitEstimator(gasLimit uint64) TxModifier {
+	return func(ctx context.Context, req *TxRequest) error {
+		req.Gas = gasLimit
+		return nil
+	}
+}
+
+// GasFeeEstimator sets a fixed legacy gas price. Use DynamicGasFeeEstimator
+// for EIP-1559 transactions.
+func GasFeeEstimator(gasPrice *big.Int) TxModifier {
+	return func(ctx context.Context, req *TxRequest) error {
+		req.GasPrice = gasPrice
+		return nil
+	}
+}
+
+// DynamicGasFeeEstimator sets a fixed EIP-1559 fee cap and tip, producing a
+// dynamic-fee (type-2) transaction.
+func DynamicGasFeeEstimator(gasTipCap, gasFeeCap *big.Int) TxModifier {
+	return func(ctx context.Context, req *TxRequest) error {
+		req.GasTipCap = gasTipCap
+		req.GasFeeCap = gasFeeCap
+		return nil
+	}
+}
+
+// ChainIDModifier stamps the request with the chain id resolved by the
+// given provider.
+func ChainIDModifier(provider *ChainIDProvider) TxModifier {
+	return func(ctx context.Context, req *TxRequest) error {
+		chainID, err := provider.ChainID(ctx)
+		if err != nil {
+			return err
+		}
+		req.ChainID = chainID
+		return nil
+	}
+}
+
+// SignerModifier builds the final transaction from the request's fields and
+// signs it with the given key. It must run last in the pipeline.
+func SignerModifier(key *ecdsa.PrivateKey) TxModifier {
+	return func(ctx context.Context, req *TxRequest) error {
+		var txData types.TxData
+		switch {
+		case req.GasFeeCap != nil:
+			txData = &types.DynamicFeeTx{
+				ChainID:   req.ChainID,
+				Nonce:     req.Nonce,
+				To:        req.To,
+				Value:     req.Value,
+				Gas:       req.Gas,
+				GasTipCap: req.GasTipCap,
+				GasFeeCap: req.GasFeeCap,
+				Data:      req.Data,
+			}
+		default:
+			txData = &types.LegacyTx{
+				Nonce:    req.Nonce,
+				To:       req.To,
+				Value:    req.Value,
+				Gas:      req.Gas,
+				GasPrice: req.GasPrice,
+				Data:     req.Data,
+			}
+		}
+
+		signer := types.LatestSignerForChainID(req.ChainID)
+		signedTx, err := types.SignNewTx(key, signer, txData)
+		if err != nil {
+			return err
+		}
+		req.signed = signedTx
+		return nil
+	}
+}
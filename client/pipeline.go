@@ -0,0 +1,70 @@
+package client
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// Pipeline applies a sequence of TxModifiers to a TxRequest and returns the
+// fully populated, signed transaction. It lets callers declare only what
+// they care about (`to`, `value`, `data`) and get the rest filled in by the
+// shared modifier chain.
+type Pipeline struct {
+	modifiers []TxModifier
+}
+
+// NewPipeline creates a pipeline that applies the given modifiers in order.
+func NewPipeline(modifiers ...TxModifier) *Pipeline {
+	return &Pipeline{modifiers: modifiers}
+}
+
+// Build runs the pipeline over a new request for the given recipient,
+// value, and calldata, returning the signed transaction.
+func (p *Pipeline) Build(ctx context.Context, to *common.Address, value *big.Int, data []byte) (*types.Transaction, error) {
+	req := &TxRequest{To: to, Value: value, Data: data}
+	for _, modify := range p.modifiers {
+		if err := modify(ctx, req); err != nil {
+			return nil, fmt.Errorf("apply tx modifier: %w", err)
+		}
+	}
+	if req.signed == nil {
+		return nil, fmt.Errorf("pipeline did not produce a signed transaction; is SignerModifier missing?")
+	}
+	return req.signed, nil
+}
+
+// sender is the subset of ethclient.Client that account+network-aware
+// default pipelines need.
+type sender interface {
+	ChainReader
+	NonceAccountReader
+}
+
+// DefaultLegacyPipeline wires the standard modifier chain used by the
+// gateway's own tests: nonce lookup, a fixed gas limit and price, chain id
+// lookup, and signing.
+func DefaultLegacyPipeline(ec sender, account common.Address, key *ecdsa.PrivateKey, gasLimit uint64, gasPrice *big.Int) *Pipeline {
+	return NewPipeline(
+		NonceProvider(ec, account),
+		GasLimitEstimator(gasLimit),
+		GasFeeEstimator(gasPrice),
+		ChainIDModifier(NewChainIDProvider(ec)),
+		SignerModifier(key),
+	)
+}
+
+// DefaultDynamicFeePipeline is the EIP-1559 equivalent of DefaultLegacyPipeline.
+func DefaultDynamicFeePipeline(ec sender, account common.Address, key *ecdsa.PrivateKey, gasLimit uint64, gasTipCap, gasFeeCap *big.Int) *Pipeline {
+	return NewPipeline(
+		NonceProvider(ec, account),
+		GasLimitEstimator(gasLimit),
+		DynamicGasFeeEstimator(gasTipCap, gasFeeCap),
+		ChainIDModifier(NewChainIDProvider(ec)),
+		SignerModifier(key),
+	)
+}
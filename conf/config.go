@@ -2,6 +2,8 @@ package conf
 
 import (
 	"fmt"
+	"os"
+	"path/filepath"
 	"strings"
 	"time"
 
@@ -9,7 +11,9 @@ import (
 	"github.com/knadh/koanf/parsers/yaml"
 	"github.com/knadh/koanf/providers/env"
 	"github.com/knadh/koanf/providers/file"
+	"github.com/knadh/koanf/providers/posflag"
 	"github.com/oasisprotocol/oasis-core/go/common/logging"
+	flag "github.com/spf13/pflag"
 )
 
 // Config contains the CLI configuration.
@@ -100,11 +104,219 @@ type GatewayConfig struct {
 
 	// ChainID defines the Ethereum network chain id.
 	ChainID uint32 `koanf:"chain_id"`
+
+	// RateLimit configures per-IP and global JSON-RPC rate limiting.
+	RateLimit *RateLimitConfig `koanf:"rate_limit"`
+
+	// Methods configures per-namespace and per-method JSON-RPC access.
+	Methods *MethodsConfig `koanf:"methods"`
+
+	// Confidential configures support for Sapphire-style confidential
+	// (encrypted) calls and transactions.
+	Confidential *ConfidentialConfig `koanf:"confidential"`
+
+	// Fee configures how the gateway synthesizes a base fee per block for
+	// eth_feeHistory/eth_maxPriorityFeePerGas, since the Oasis runtime does
+	// not itself expose an EIP-1559 base fee.
+	Fee *FeeConfig `koanf:"fee"`
 }
 
 // Validate validates the gateway configuration.
 func (cfg *GatewayConfig) Validate() error {
-	// TODO:
+	if cfg.RateLimit != nil {
+		if err := cfg.RateLimit.Validate(); err != nil {
+			return fmt.Errorf("rate_limit: %w", err)
+		}
+	}
+	if cfg.Methods != nil {
+		if err := cfg.Methods.Validate(); err != nil {
+			return fmt.Errorf("methods: %w", err)
+		}
+	}
+	if cfg.Confidential != nil {
+		if err := cfg.Confidential.Validate(); err != nil {
+			return fmt.Errorf("confidential: %w", err)
+		}
+	}
+	if cfg.Fee != nil {
+		if err := cfg.Fee.Validate(); err != nil {
+			return fmt.Errorf("fee: %w", err)
+		}
+	}
+	return nil
+}
+
+// FeeModel selects how the gateway derives a block's synthesized base fee.
+type FeeModel string
+
+const (
+	// FeeModelConstant always reports the same base fee.
+	FeeModelConstant FeeModel = "constant"
+	// FeeModelLinear adjusts the base fee linearly around a target gas used
+	// ratio, the same direction (up when blocks run full, down when they
+	// run empty) as go-ethereum's EIP-1559 formula, but without requiring a
+	// parent base fee from the Oasis runtime.
+	FeeModelLinear FeeModel = "linear"
+)
+
+// FeeConfig controls base fee synthesis and the rolling window of recent
+// block fee data kept for eth_feeHistory.
+type FeeConfig struct {
+	// Model selects "constant" or "linear" base fee synthesis. Defaults to
+	// "constant".
+	Model FeeModel `koanf:"model"`
+
+	// ConstantGwei is the base fee reported by the "constant" model, in gwei.
+	ConstantGwei uint64 `koanf:"constant_gwei"`
+
+	// LinearBaseGwei is the "linear" model's base fee at the target gas
+	// used ratio, in gwei.
+	LinearBaseGwei uint64 `koanf:"linear_base_gwei"`
+
+	// LinearSlopeGwei is how much the "linear" model's base fee moves, in
+	// gwei, per 1.0 of gas-used-ratio deviation from TargetGasUsedRatio.
+	LinearSlopeGwei uint64 `koanf:"linear_slope_gwei"`
+
+	// TargetGasUsedRatio is the gas-used-ratio (0.0-1.0) the "linear" model
+	// treats as equilibrium. Defaults to 0.5.
+	TargetGasUsedRatio float64 `koanf:"target_gas_used_ratio"`
+
+	// WindowSize is how many recent blocks' fee data are kept in memory for
+	// eth_feeHistory, bounding both memory use and the maximum blockCount a
+	// single call can serve without consulting the log store.
+	WindowSize int `koanf:"window_size"`
+}
+
+// Validate validates the fee configuration.
+func (cfg *FeeConfig) Validate() error {
+	switch cfg.Model {
+	case "", FeeModelConstant, FeeModelLinear:
+	default:
+		return fmt.Errorf("invalid fee model '%s'", cfg.Model)
+	}
+	if cfg.TargetGasUsedRatio < 0 || cfg.TargetGasUsedRatio > 1 {
+		return fmt.Errorf("target_gas_used_ratio must be between 0 and 1")
+	}
+	if cfg.WindowSize < 0 {
+		return fmt.Errorf("window_size must not be negative")
+	}
+	return nil
+}
+
+// ConfidentialPolicy controls whether the gateway wraps plaintext calldata
+// in an encrypted envelope on the caller's behalf.
+type ConfidentialPolicy string
+
+const (
+	// ConfidentialOff never wraps plaintext calldata; callers must submit
+	// their own envelopes to reach a confidential runtime.
+	ConfidentialOff ConfidentialPolicy = "off"
+	// ConfidentialOptIn wraps plaintext calldata only for callers that
+	// explicitly request it (via header or per-call flag).
+	ConfidentialOptIn ConfidentialPolicy = "opt-in"
+	// ConfidentialAlways wraps all plaintext eth_call/eth_estimateGas/raw
+	// transaction calldata automatically.
+	ConfidentialAlways ConfidentialPolicy = "always"
+)
+
+// ConfidentialConfig controls the gateway's confidential call/tx subsystem.
+type ConfidentialConfig struct {
+	// Policy is the default wrapping policy: "off", "opt-in", or "always".
+	Policy ConfidentialPolicy `koanf:"policy"`
+
+	// PublicKeyCacheTTLSeconds bounds how long the runtime's call data
+	// public key is cached for before being re-fetched.
+	PublicKeyCacheTTLSeconds int `koanf:"public_key_cache_ttl_seconds"`
+}
+
+// Validate validates the confidential configuration.
+func (cfg *ConfidentialConfig) Validate() error {
+	switch cfg.Policy {
+	case "", ConfidentialOff, ConfidentialOptIn, ConfidentialAlways:
+	default:
+		return fmt.Errorf("invalid confidential policy '%s'", cfg.Policy)
+	}
+	if cfg.PublicKeyCacheTTLSeconds < 0 {
+		return fmt.Errorf("public_key_cache_ttl_seconds must not be negative")
+	}
+	return nil
+}
+
+// RateLimitConfig controls JSON-RPC request rate limiting, enforced by the
+// HTTP and WS handlers before a request is dispatched to its namespace.
+type RateLimitConfig struct {
+	// Enabled turns rate limiting on or off.
+	Enabled bool `koanf:"enabled"`
+
+	// PerIPQPS is the sustained per-remote-IP token-bucket rate, in
+	// requests per second.
+	PerIPQPS float64 `koanf:"per_ip_qps"`
+
+	// PerIPBurst is the per-remote-IP token-bucket burst size.
+	PerIPBurst int `koanf:"per_ip_burst"`
+
+	// GlobalQPS is a process-wide cap across all remotes, independent of
+	// the per-IP limits. Zero means unlimited.
+	GlobalQPS float64 `koanf:"global_qps"`
+
+	// MethodCosts assigns a token cost to specific methods (default 1),
+	// so that expensive calls like eth_getLogs count for more than a
+	// single request against the token buckets above.
+	MethodCosts map[string]int `koanf:"method_costs"`
+
+	// MaxBatchSize is the maximum number of requests allowed in a single
+	// JSON-RPC batch.
+	MaxBatchSize int `koanf:"max_batch_size"`
+
+	// MaxResponseSize caps the serialized size, in bytes, of a single
+	// response, guarding against expensive eth_getLogs-style calls.
+	MaxResponseSize int `koanf:"max_response_size"`
+}
+
+// Validate validates the rate limit configuration.
+func (cfg *RateLimitConfig) Validate() error {
+	if !cfg.Enabled {
+		return nil
+	}
+	if cfg.PerIPQPS < 0 || cfg.GlobalQPS < 0 {
+		return fmt.Errorf("qps limits must not be negative")
+	}
+	if cfg.MaxBatchSize < 0 {
+		return fmt.Errorf("max_batch_size must not be negative")
+	}
+	if cfg.MaxResponseSize < 0 {
+		return fmt.Errorf("max_response_size must not be negative")
+	}
+	for method, cost := range cfg.MethodCosts {
+		if cost <= 0 {
+			return fmt.Errorf("method cost for '%s' must be positive", method)
+		}
+	}
+	return nil
+}
+
+// MethodsConfig controls which JSON-RPC namespaces and methods are served.
+type MethodsConfig struct {
+	// Namespaces enables or disables whole namespaces, e.g. "eth", "net",
+	// "web3", "txpool", "debug". A namespace not listed defaults to enabled,
+	// except "debug" which defaults to disabled.
+	Namespaces map[string]bool `koanf:"namespaces"`
+
+	// Allow, if non-empty, restricts calls to exactly this set of full
+	// method names (e.g. "eth_getLogs"). Allow and Deny are mutually
+	// exclusive; Validate rejects a config that sets both.
+	Allow []string `koanf:"allow"`
+
+	// Deny lists full method names that are rejected even if their
+	// namespace is enabled.
+	Deny []string `koanf:"deny"`
+}
+
+// Validate validates the methods configuration.
+func (cfg *MethodsConfig) Validate() error {
+	if len(cfg.Allow) > 0 && len(cfg.Deny) > 0 {
+		return fmt.Errorf("allow and deny lists are mutually exclusive")
+	}
 	return nil
 }
 
@@ -153,16 +365,40 @@ type GatewayWSConfig struct {
 	Timeouts *HTTPTimeouts `koanf:"timeouts"`
 }
 
-// InitConfig initializes configuration from file.
-func InitConfig(f string) (*Config, error) {
+// envOverlayVar is the environment variable that selects the optional
+// environment-specific overlay file loaded on top of the base config, e.g.
+// "config.yaml" + "config.production.yaml" when OASIS_WEB3_GATEWAY_ENV=production
+// is set.
+const envOverlayVar = "OASIS_WEB3_GATEWAY_ENV"
+
+// InitConfig initializes configuration by layering, in increasing order of
+// precedence:
+//  1. the base YAML config file `f`;
+//  2. an environment-specific overlay file, if $OASIS_WEB3_GATEWAY_ENV is
+//     set (e.g. "config.yaml" -> "config.production.yaml");
+//  3. a local overrides file "config.local.yaml" alongside `f`, if present;
+//  4. environment variables;
+//  5. CLI flags bound via `flags`, if non-nil.
+//
+// Each layer is merged with koanf's default merge semantics, so later
+// layers override only the keys they explicitly set.
+func InitConfig(f string, flags *flag.FlagSet) (*Config, error) {
 	var config Config
 	k := koanf.New(".")
 
-	// Load configuration from the yaml config.
 	if err := k.Load(file.Provider(f), yaml.Parser()); err != nil {
 		return nil, err
 	}
 
+	for _, overlay := range overlayPaths(f) {
+		if _, err := os.Stat(overlay); err != nil {
+			continue
+		}
+		if err := k.Load(file.Provider(overlay), yaml.Parser()); err != nil {
+			return nil, fmt.Errorf("load config overlay '%s': %w", overlay, err)
+		}
+	}
+
 	// Load environment variables and merge into the loaded config.
 	if err := k.Load(env.Provider("", ".", func(s string) string {
 		// `__` is used as a hierarchy delimiter.
@@ -171,6 +407,14 @@ func InitConfig(f string) (*Config, error) {
 		return nil, err
 	}
 
+	// Bind CLI flags last so they take precedence over file and env config,
+	// e.g. `--gateway.http.port` or `--database.password`.
+	if flags != nil {
+		if err := k.Load(posflag.Provider(flags, ".", k), nil); err != nil {
+			return nil, fmt.Errorf("bind CLI flags: %w", err)
+		}
+	}
+
 	// Unmarshal into config.
 	if err := k.Unmarshal("", &config); err != nil {
 		return nil, err
@@ -183,3 +427,22 @@ func InitConfig(f string) (*Config, error) {
 
 	return &config, nil
 }
+
+// overlayPaths returns the environment-specific and local overlay paths for
+// a base config path, in the order they should be applied.
+func overlayPaths(base string) []string {
+	var overlays []string
+	if env := os.Getenv(envOverlayVar); env != "" {
+		overlays = append(overlays, withSuffix(base, env))
+	}
+	overlays = append(overlays, withSuffix(base, "local"))
+	return overlays
+}
+
+// withSuffix inserts a suffix before a config file's extension, e.g.
+// withSuffix("config.yaml", "local") -> "config.local.yaml".
+func withSuffix(path, suffix string) string {
+	ext := filepath.Ext(path)
+	name := strings.TrimSuffix(path, ext)
+	return name + "." + suffix + ext
+}
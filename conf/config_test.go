@@ -0,0 +1,22 @@
+package conf
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestWithSuffix(t *testing.T) {
+	require.Equal(t, "config.local.yaml", withSuffix("config.yaml", "local"))
+	require.Equal(t, "config.dev.yml", withSuffix("config.yml", "dev"))
+	require.Equal(t, "config.local", withSuffix("config", "local"))
+}
+
+func TestOverlayPaths(t *testing.T) {
+	require.NoError(t, os.Unsetenv(envOverlayVar))
+	require.Equal(t, []string{"config.local.yaml"}, overlayPaths("config.yaml"))
+
+	t.Setenv(envOverlayVar, "ci")
+	require.Equal(t, []string{"config.ci.yaml", "config.local.yaml"}, overlayPaths("config.yaml"))
+}
@@ -0,0 +1,158 @@
+package conf
+
+import (
+	"fmt"
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+
+	flag "github.com/spf13/pflag"
+)
+
+// immutableField names a Config path that cannot change across a hot
+// reload, since swapping it at runtime would require re-establishing the
+// DB connection or runtime client rather than just updating in-memory
+// state, plus an accessor that stringifies that path's current value so two
+// configs can be compared without a bespoke field-by-field diff.
+type immutableField struct {
+	path  string
+	value func(cfg *Config) string
+}
+
+// immutableFields are the Config paths requireImmutableFieldsUnchanged
+// actually enforces; add to this list (not a separate hardcoded check) to
+// protect a new immutable field.
+var immutableFields = []immutableField{
+	{"runtime_id", func(cfg *Config) string { return cfg.RuntimeID }},
+	{"gateway.chain_id", func(cfg *Config) string {
+		if cfg.Gateway == nil {
+			return ""
+		}
+		return fmt.Sprintf("%d", cfg.Gateway.ChainID)
+	}},
+	{"database.host", func(cfg *Config) string {
+		return databaseFieldOrEmpty(cfg, func(db *DatabaseConfig) string { return db.Host })
+	}},
+	{"database.port", func(cfg *Config) string {
+		return databaseFieldOrEmpty(cfg, func(db *DatabaseConfig) string { return fmt.Sprintf("%d", db.Port) })
+	}},
+	{"database.db", func(cfg *Config) string {
+		return databaseFieldOrEmpty(cfg, func(db *DatabaseConfig) string { return db.DB })
+	}},
+	{"database.user", func(cfg *Config) string {
+		return databaseFieldOrEmpty(cfg, func(db *DatabaseConfig) string { return db.User })
+	}},
+	{"database.password", func(cfg *Config) string {
+		return databaseFieldOrEmpty(cfg, func(db *DatabaseConfig) string { return db.Password })
+	}},
+}
+
+func databaseFieldOrEmpty(cfg *Config, get func(*DatabaseConfig) string) string {
+	if cfg.Database == nil {
+		return ""
+	}
+	return get(cfg.Database)
+}
+
+// Reloader re-parses the gateway configuration on SIGHUP and atomically
+// swaps the whole new configuration in, handing it to an onReload callback
+// that applies it to the running server without a restart.
+type Reloader struct {
+	path  string
+	flags *flag.FlagSet
+
+	mu  sync.RWMutex
+	cur *Config
+
+	onReload func(*Config)
+}
+
+// NewReloader creates a Reloader that re-reads configuration from `path`
+// (plus its overlays, env vars, and `flags`) on every reload, starting from
+// the already-loaded `initial` config. `onReload` is invoked with the new
+// config after a successful, validated reload.
+func NewReloader(path string, flags *flag.FlagSet, initial *Config, onReload func(*Config)) *Reloader {
+	return &Reloader{
+		path:     path,
+		flags:    flags,
+		cur:      initial,
+		onReload: onReload,
+	}
+}
+
+// Current returns the most recently loaded configuration.
+func (r *Reloader) Current() *Config {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.cur
+}
+
+// Reload re-parses the configuration and, if none of immutableFields
+// changed, swaps it in and invokes onReload. It returns an error without
+// changing state if parsing fails, validation fails, or an immutable field
+// would change. Changes to any field not in immutableFields are accepted
+// as they are; Reload does not restrict them to a hot-reloadable subset.
+func (r *Reloader) Reload() error {
+	next, err := InitConfig(r.path, r.flags)
+	if err != nil {
+		return fmt.Errorf("reload config: %w", err)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if err := requireImmutableFieldsUnchanged(r.cur, next); err != nil {
+		return err
+	}
+
+	r.cur = next
+	if r.onReload != nil {
+		r.onReload(next)
+	}
+	return nil
+}
+
+// ListenForSIGHUP reloads the configuration every time the process receives
+// SIGHUP, logging (via the returned channel of errors) rather than exiting
+// on a bad reload so a typo in the config can't take the gateway down. The
+// returned channel is buffered; if a caller falls behind and it fills up,
+// the oldest queued error is dropped to make room rather than blocking the
+// reload goroutine, since a future SIGHUP must never be silently disabled
+// by a consumer that isn't keeping up.
+func (r *Reloader) ListenForSIGHUP() <-chan error {
+	sighup := make(chan os.Signal, 1)
+	signal.Notify(sighup, syscall.SIGHUP)
+
+	errc := make(chan error, 8)
+	go func() {
+		for range sighup {
+			err := r.Reload()
+			if err == nil {
+				continue
+			}
+			select {
+			case errc <- err:
+			default:
+				<-errc
+				errc <- err
+			}
+		}
+	}()
+	return errc
+}
+
+// requireImmutableFieldsUnchanged rejects a reload that would change any of
+// immutableFields (chain id, runtime id, DB DSN).
+func requireImmutableFieldsUnchanged(cur, next *Config) error {
+	for _, f := range immutableFields {
+		if f.value(cur) != f.value(next) {
+			return immutableFieldErr(f.path)
+		}
+	}
+	return nil
+}
+
+func immutableFieldErr(field string) error {
+	return fmt.Errorf("config hot reload: '%s' is immutable and cannot be changed without a restart", field)
+}
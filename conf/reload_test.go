@@ -0,0 +1,39 @@
+package conf
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRequireImmutableFieldsUnchanged_NoChange(t *testing.T) {
+	cur := &Config{
+		RuntimeID: "deadbeef",
+		Gateway:   &GatewayConfig{ChainID: 42},
+		Database:  &DatabaseConfig{Host: "localhost", Port: 5432, DB: "gw", User: "gw", Password: "secret"},
+	}
+	next := &Config{
+		RuntimeID: "deadbeef",
+		Gateway:   &GatewayConfig{ChainID: 42},
+		Database:  &DatabaseConfig{Host: "localhost", Port: 5432, DB: "gw", User: "gw", Password: "secret"},
+	}
+	require.NoError(t, requireImmutableFieldsUnchanged(cur, next))
+}
+
+func TestRequireImmutableFieldsUnchanged_RuntimeIDChanged(t *testing.T) {
+	cur := &Config{RuntimeID: "deadbeef"}
+	next := &Config{RuntimeID: "cafebabe"}
+	require.Error(t, requireImmutableFieldsUnchanged(cur, next))
+}
+
+func TestRequireImmutableFieldsUnchanged_ChainIDChanged(t *testing.T) {
+	cur := &Config{Gateway: &GatewayConfig{ChainID: 42}}
+	next := &Config{Gateway: &GatewayConfig{ChainID: 43}}
+	require.Error(t, requireImmutableFieldsUnchanged(cur, next))
+}
+
+func TestRequireImmutableFieldsUnchanged_DatabaseFieldChanged(t *testing.T) {
+	cur := &Config{Database: &DatabaseConfig{Host: "localhost"}}
+	next := &Config{Database: &DatabaseConfig{Host: "otherhost"}}
+	require.Error(t, requireImmutableFieldsUnchanged(cur, next))
+}
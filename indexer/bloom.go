@@ -0,0 +1,21 @@
+package indexer
+
+import (
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// ComputeBlockBloom computes a block's logs bloom as the union of its
+// transaction receipts' blooms, mirroring go-ethereum's own block header
+// derivation. Blocks synthesized by the indexer otherwise carry no EVM
+// header and would not populate this field on their own; the indexer calls
+// this when persisting a block so that `logsBloom` is available without
+// re-scanning receipts on every eth_getBlockBy* or eth_getLogs call.
+func ComputeBlockBloom(receipts []*types.Receipt) types.Bloom {
+	var bloom types.Bloom
+	for _, receipt := range receipts {
+		for i, b := range receipt.Bloom.Bytes() {
+			bloom[i] |= b
+		}
+	}
+	return bloom
+}
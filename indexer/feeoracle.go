@@ -0,0 +1,136 @@
+package indexer
+
+import (
+	"fmt"
+	"math/big"
+	"sync"
+
+	"github.com/starfishlabs/oasis-evm-web3-gateway/conf"
+	"github.com/starfishlabs/oasis-evm-web3-gateway/rpc/backend"
+)
+
+// gwei is the multiplier from gwei to wei.
+var gwei = big.NewInt(1_000_000_000)
+
+// defaultWindowSize bounds the rolling window of recent blocks' fee data
+// kept in memory when the config does not set one explicitly.
+const defaultWindowSize = 1024
+
+// BaseFeeOracle synthesizes a base fee per block (the Oasis runtime has no
+// native EIP-1559 base fee of its own) and keeps a rolling window of recent
+// blocks' fee data in memory, so eth_feeHistory does not need to re-read
+// every block from storage on every call.
+//
+// The indexer calls Record once per block, right after it computes the
+// block's bloom (see bloom.go) and gas usage, so that BlockFeeInfo can
+// answer from memory for any block still within the window.
+type BaseFeeOracle struct {
+	cfg *conf.FeeConfig
+
+	mu      sync.RWMutex
+	window  []*backend.BlockFeeInfo // ordered oldest to newest
+	byBlock map[uint64]*backend.BlockFeeInfo
+}
+
+// NewBaseFeeOracle creates a BaseFeeOracle from the gateway's fee config. A
+// nil cfg uses the constant-fee default.
+func NewBaseFeeOracle(cfg *conf.FeeConfig) *BaseFeeOracle {
+	return &BaseFeeOracle{
+		cfg:     cfg,
+		byBlock: make(map[uint64]*backend.BlockFeeInfo),
+	}
+}
+
+// ComputeBaseFee synthesizes the base fee for a block given its gas used,
+// gas limit, and (for the linear model) the configured target utilization.
+func (o *BaseFeeOracle) ComputeBaseFee(gasUsed, gasLimit uint64) *big.Int {
+	switch resolveFeeModel(o.cfg) {
+	case conf.FeeModelLinear:
+		return o.computeLinearBaseFee(gasUsed, gasLimit)
+	default:
+		return o.computeConstantBaseFee()
+	}
+}
+
+func (o *BaseFeeOracle) computeConstantBaseFee() *big.Int {
+	gweiAmount := uint64(1) // 1 gwei default when unconfigured.
+	if o.cfg != nil && o.cfg.ConstantGwei > 0 {
+		gweiAmount = o.cfg.ConstantGwei
+	}
+	return new(big.Int).Mul(new(big.Int).SetUint64(gweiAmount), gwei)
+}
+
+func (o *BaseFeeOracle) computeLinearBaseFee(gasUsed, gasLimit uint64) *big.Int {
+	base := o.cfg.LinearBaseGwei
+	if base == 0 {
+		base = 1
+	}
+	target := o.cfg.TargetGasUsedRatio
+	if target == 0 {
+		target = 0.5
+	}
+
+	var ratio float64
+	if gasLimit > 0 {
+		ratio = float64(gasUsed) / float64(gasLimit)
+	}
+	delta := ratio - target
+
+	// Scale the configured slope by how far utilization is from the
+	// target, in either direction; this mirrors the direction (not the
+	// exact formula) of go-ethereum's EIP-1559 base fee adjustment.
+	adjustment := int64(delta * float64(o.cfg.LinearSlopeGwei))
+	feeGwei := int64(base) + adjustment
+	if feeGwei < 1 {
+		feeGwei = 1
+	}
+
+	return new(big.Int).Mul(big.NewInt(feeGwei), gwei)
+}
+
+// Record stores a block's fee info in the rolling window, evicting the
+// oldest entry once WindowSize is exceeded.
+func (o *BaseFeeOracle) Record(info *backend.BlockFeeInfo) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	o.window = append(o.window, info)
+	o.byBlock[info.Number] = info
+
+	limit := windowSizeOf(o.cfg)
+	for len(o.window) > limit {
+		oldest := o.window[0]
+		o.window = o.window[1:]
+		delete(o.byBlock, oldest.Number)
+	}
+}
+
+// Get returns the cached fee info for a block number, if it is still within
+// the rolling window.
+func (o *BaseFeeOracle) Get(number uint64) (*backend.BlockFeeInfo, error) {
+	o.mu.RLock()
+	defer o.mu.RUnlock()
+
+	info, ok := o.byBlock[number]
+	if !ok {
+		return nil, fmt.Errorf("block %d fee info is outside the rolling window", number)
+	}
+	return info, nil
+}
+
+// windowSizeOf returns the configured rolling window size, or the default.
+func windowSizeOf(cfg *conf.FeeConfig) int {
+	if cfg == nil || cfg.WindowSize <= 0 {
+		return defaultWindowSize
+	}
+	return cfg.WindowSize
+}
+
+// resolveFeeModel returns the configured fee model, defaulting to constant
+// when cfg is nil or unset.
+func resolveFeeModel(cfg *conf.FeeConfig) conf.FeeModel {
+	if cfg == nil || cfg.Model == "" {
+		return conf.FeeModelConstant
+	}
+	return cfg.Model
+}
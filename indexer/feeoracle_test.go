@@ -0,0 +1,58 @@
+package indexer
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/starfishlabs/oasis-evm-web3-gateway/conf"
+	"github.com/starfishlabs/oasis-evm-web3-gateway/rpc/backend"
+)
+
+func TestBaseFeeOracle_ConstantModel(t *testing.T) {
+	oracle := NewBaseFeeOracle(&conf.FeeConfig{Model: conf.FeeModelConstant, ConstantGwei: 5})
+
+	fee := oracle.ComputeBaseFee(1_000_000, 10_000_000)
+	require.EqualValues(t, 5_000_000_000, fee.Uint64())
+
+	// Constant model ignores gas usage entirely.
+	fee2 := oracle.ComputeBaseFee(10_000_000, 10_000_000)
+	require.Equal(t, fee, fee2)
+}
+
+func TestBaseFeeOracle_LinearModelRisesAboveTarget(t *testing.T) {
+	oracle := NewBaseFeeOracle(&conf.FeeConfig{
+		Model:              conf.FeeModelLinear,
+		LinearBaseGwei:     10,
+		LinearSlopeGwei:    10,
+		TargetGasUsedRatio: 0.5,
+	})
+
+	atTarget := oracle.ComputeBaseFee(5_000_000, 10_000_000)
+	require.EqualValues(t, 10_000_000_000, atTarget.Uint64())
+
+	full := oracle.ComputeBaseFee(10_000_000, 10_000_000)
+	require.Greater(t, full.Uint64(), atTarget.Uint64(), "base fee should rise when blocks run above target utilization")
+
+	empty := oracle.ComputeBaseFee(0, 10_000_000)
+	require.Less(t, empty.Uint64(), atTarget.Uint64(), "base fee should fall when blocks run below target utilization")
+}
+
+func TestBaseFeeOracle_RollingWindowEvictsOldest(t *testing.T) {
+	oracle := NewBaseFeeOracle(&conf.FeeConfig{WindowSize: 2})
+
+	oracle.Record(&backend.BlockFeeInfo{Number: 1})
+	oracle.Record(&backend.BlockFeeInfo{Number: 2})
+	oracle.Record(&backend.BlockFeeInfo{Number: 3})
+
+	_, err := oracle.Get(1)
+	require.Error(t, err, "block 1 should have been evicted once the window exceeded its size")
+
+	info2, err := oracle.Get(2)
+	require.NoError(t, err)
+	require.EqualValues(t, 2, info2.Number)
+
+	info3, err := oracle.Get(3)
+	require.NoError(t, err)
+	require.EqualValues(t, 3, info3.Number)
+}
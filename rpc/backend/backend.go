@@ -0,0 +1,74 @@
+// Package backend defines the interface through which the rpc namespaces
+// (eth, net, web3, ...) reach into the indexer and the Oasis runtime client,
+// without depending on their concrete implementations directly.
+package backend
+
+import (
+	"context"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// BlockFeeInfo carries the per-block fee data needed to answer
+// eth_feeHistory and eth_maxPriorityFeePerGas without re-scanning receipts.
+type BlockFeeInfo struct {
+	// Number is the block number.
+	Number uint64
+	// BaseFee is the base fee per gas synthesized for this block.
+	BaseFee *big.Int
+	// GasUsed is the total gas used by the block.
+	GasUsed uint64
+	// GasLimit is the block gas limit.
+	GasLimit uint64
+	// Rewards are the effective priority fees (tip) of every transaction
+	// included in the block, sorted ascending, used to compute the
+	// requested reward percentiles.
+	Rewards []*big.Int
+}
+
+// Backend is the subset of gateway functionality that the rpc namespaces
+// depend on.
+type Backend interface {
+	// ChainID returns the configured Ethereum chain id.
+	ChainID() uint32
+
+	// BlockNumber returns the most recently indexed block number.
+	BlockNumber(ctx context.Context) (uint64, error)
+
+	// BlockFeeInfo returns the fee data for the given block number, used to
+	// serve eth_feeHistory and eth_maxPriorityFeePerGas.
+	BlockFeeInfo(ctx context.Context, number uint64) (*BlockFeeInfo, error)
+
+	// BlockBloom returns the logs bloom for the given block number.
+	BlockBloom(ctx context.Context, number uint64) ([256]byte, error)
+
+	// SubmitTransaction relays a signed transaction to the Oasis runtime
+	// and returns its hash once accepted into the submission pipeline.
+	SubmitTransaction(ctx context.Context, tx *types.Transaction) (common.Hash, error)
+
+	// BlockHashByNumber resolves a block number to its hash.
+	BlockHashByNumber(ctx context.Context, number uint64) (common.Hash, error)
+
+	// BlockNumberByHash resolves a block hash to its number.
+	BlockNumberByHash(ctx context.Context, hash common.Hash) (uint64, error)
+
+	// BlockByNumber returns the indexed block header data for a block number.
+	BlockByNumber(ctx context.Context, number uint64) (*Block, error)
+
+	// BlockByHash returns the indexed block header data for a block hash.
+	BlockByHash(ctx context.Context, hash common.Hash) (*Block, error)
+}
+
+// Block is the subset of a synthesized block's header data that the eth
+// namespace needs to answer eth_getBlockByNumber/eth_getBlockByHash.
+type Block struct {
+	Number       uint64
+	Hash         common.Hash
+	ParentHash   common.Hash
+	Timestamp    uint64
+	GasUsed      uint64
+	GasLimit     uint64
+	Transactions []common.Hash
+}
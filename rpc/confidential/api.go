@@ -0,0 +1,80 @@
+package confidential
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// PublicAPI implements the "oasis" namespace's confidential-call support.
+type PublicAPI struct {
+	keys   *KeyCache
+	policy func() ConfidentialPolicy
+}
+
+// ConfidentialPolicy mirrors conf.ConfidentialPolicy without importing the
+// conf package directly, so this package has no dependency on gateway
+// configuration types; the gateway wires the two together at startup.
+type ConfidentialPolicy string
+
+const (
+	PolicyOff    ConfidentialPolicy = "off"
+	PolicyOptIn  ConfidentialPolicy = "opt-in"
+	PolicyAlways ConfidentialPolicy = "always"
+)
+
+// NewPublicAPI creates a new "oasis" namespace API instance. `policy`
+// returns the gateway's current confidential-call policy, so that it can be
+// hot-reloaded without having to reconstruct the API.
+func NewPublicAPI(keys *KeyCache, policy func() ConfidentialPolicy) *PublicAPI {
+	return &PublicAPI{keys: keys, policy: policy}
+}
+
+// CallDataPublicKey implements oasis_callDataPublicKey, returning the
+// runtime's cached ephemeral public key used to encrypt confidential call
+// data.
+func (api *PublicAPI) CallDataPublicKey(ctx context.Context) (*CallDataPublicKey, error) {
+	return api.keys.Get(ctx)
+}
+
+// PrepareCallData inspects `data` passed to eth_call/eth_estimateGas and
+// decides what the dispatcher should send to the runtime:
+//   - an already-encrypted envelope is forwarded unchanged;
+//   - plaintext calldata is wrapped when the policy is "always", or when
+//     "opt-in" and the caller set optIn;
+//   - otherwise plaintext is forwarded unchanged (the runtime will reject it
+//     if the call actually requires confidentiality).
+func (api *PublicAPI) PrepareCallData(ctx context.Context, data []byte, optIn bool) ([]byte, error) {
+	if IsEnvelope(data) {
+		return data, nil
+	}
+
+	switch api.policy() {
+	case PolicyAlways:
+	case PolicyOptIn:
+		if !optIn {
+			return data, nil
+		}
+	default:
+		return data, nil
+	}
+
+	key, err := api.keys.Get(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("prepare confidential call data: %w", err)
+	}
+	return Encrypt(data, key)
+}
+
+// OptInFromHeader reports whether the client opted into confidential
+// wrapping via the X-Oasis-Confidential request header.
+func OptInFromHeader(value string) bool {
+	return value == "1" || value == "true"
+}
+
+// MarshalCallDataPublicKey is a convenience used by the RPC dispatcher to
+// hex-encode the public key field for JSON responses.
+func MarshalCallDataPublicKey(key *CallDataPublicKey) hexutil.Bytes {
+	return key.PublicKey[:]
+}
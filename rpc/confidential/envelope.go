@@ -0,0 +1,81 @@
+// Package confidential implements support for Sapphire-style confidential
+// EVM calls, where calldata is encrypted to the runtime enclave's ephemeral
+// public key before it ever reaches the chain.
+package confidential
+
+import (
+	"bytes"
+	"encoding/json"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/fxamacker/cbor/v2"
+)
+
+// envelopeFormatKey is the CBOR map key the Oasis runtime SDK uses to tag an
+// already-encrypted call envelope, distinguishing it from plaintext
+// calldata.
+const envelopeFormatKey = "format"
+
+// IsEnvelope reports whether `data` is already an Oasis call envelope
+// (CBOR-encoded, carrying a "format" field), as opposed to plaintext EVM
+// calldata. Envelopes are forwarded unchanged; plaintext is a candidate for
+// auto-wrapping depending on the configured policy.
+func IsEnvelope(data []byte) bool {
+	if len(data) == 0 {
+		return false
+	}
+	var probe map[string]cbor.RawMessage
+	if err := cbor.Unmarshal(data, &probe); err != nil {
+		return false
+	}
+	_, ok := probe[envelopeFormatKey]
+	return ok
+}
+
+// CallDataPublicKey is the runtime's ephemeral public key used to encrypt
+// confidential call data, as returned by oasis_callDataPublicKey.
+type CallDataPublicKey struct {
+	PublicKey [32]byte `cbor:"public_key" json:"public_key"`
+	Checksum  []byte   `cbor:"checksum" json:"checksum"`
+	Signature []byte   `cbor:"signature" json:"signature"`
+	Epoch     uint64   `cbor:"epoch" json:"epoch"`
+}
+
+// Equal reports whether two public keys carry the same key material.
+func (k CallDataPublicKey) Equal(other CallDataPublicKey) bool {
+	return bytes.Equal(k.PublicKey[:], other.PublicKey[:])
+}
+
+// callDataPublicKeyJSON mirrors CallDataPublicKey but with PublicKey as a
+// hex string, matching every other byte-array field this gateway returns
+// over JSON-RPC (e.g. hexutil.Bytes).
+type callDataPublicKeyJSON struct {
+	PublicKey hexutil.Bytes `json:"public_key"`
+	Checksum  hexutil.Bytes `json:"checksum"`
+	Signature hexutil.Bytes `json:"signature"`
+	Epoch     uint64        `json:"epoch"`
+}
+
+// MarshalJSON renders the public key (and other byte fields) as 0x-prefixed
+// hex strings rather than JSON arrays of numbers.
+func (k CallDataPublicKey) MarshalJSON() ([]byte, error) {
+	return json.Marshal(callDataPublicKeyJSON{
+		PublicKey: MarshalCallDataPublicKey(&k),
+		Checksum:  hexutil.Bytes(k.Checksum),
+		Signature: hexutil.Bytes(k.Signature),
+		Epoch:     k.Epoch,
+	})
+}
+
+// UnmarshalJSON accepts the hex-encoded wire format produced by MarshalJSON.
+func (k *CallDataPublicKey) UnmarshalJSON(data []byte) error {
+	var wire callDataPublicKeyJSON
+	if err := json.Unmarshal(data, &wire); err != nil {
+		return err
+	}
+	copy(k.PublicKey[:], wire.PublicKey)
+	k.Checksum = wire.Checksum
+	k.Signature = wire.Signature
+	k.Epoch = wire.Epoch
+	return nil
+}
@@ -0,0 +1,59 @@
+package confidential
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// RuntimeClient fetches the confidential call data public key from the
+// Oasis runtime.
+type RuntimeClient interface {
+	CallDataPublicKey(ctx context.Context) (*CallDataPublicKey, error)
+}
+
+// KeyCache caches the runtime's call data public key for up to `ttl`,
+// re-fetching it from the runtime client on expiry or on first use.
+type KeyCache struct {
+	client RuntimeClient
+	ttl    time.Duration
+
+	mu        sync.Mutex
+	key       *CallDataPublicKey
+	fetchedAt time.Time
+}
+
+// NewKeyCache creates a KeyCache that re-fetches the public key from
+// `client` after `ttl` has elapsed since the last fetch. A non-positive ttl
+// disables caching; every call re-fetches.
+func NewKeyCache(client RuntimeClient, ttl time.Duration) *KeyCache {
+	return &KeyCache{client: client, ttl: ttl}
+}
+
+// Get returns the cached public key, fetching (or re-fetching, if expired)
+// it from the runtime client as needed. It backs the oasis_callDataPublicKey
+// RPC as well as internal envelope wrapping.
+func (c *KeyCache) Get(ctx context.Context) (*CallDataPublicKey, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.key != nil && c.ttl > 0 && time.Since(c.fetchedAt) < c.ttl {
+		return c.key, nil
+	}
+
+	key, err := c.client.CallDataPublicKey(ctx)
+	if err != nil {
+		if c.key != nil {
+			// Serve the stale key rather than failing outright; a transient
+			// runtime hiccup should not break confidential calls that were
+			// working a moment ago.
+			return c.key, nil
+		}
+		return nil, fmt.Errorf("fetch call data public key: %w", err)
+	}
+
+	c.key = key
+	c.fetchedAt = time.Now()
+	return c.key, nil
+}
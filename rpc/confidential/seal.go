@@ -0,0 +1,58 @@
+package confidential
+
+import (
+	"crypto/rand"
+	"fmt"
+
+	"github.com/fxamacker/cbor/v2"
+	"golang.org/x/crypto/nacl/box"
+)
+
+// sealedEnvelope is the CBOR wire format produced by Encrypt: a
+// format tag (for IsEnvelope to recognize), the caller's
+// ephemeral public key, and the NaCl sealed-box ciphertext of the plaintext
+// calldata under (callerEphemeral, runtimePublicKey).
+type sealedEnvelope struct {
+	Format    uint64 `cbor:"format"`
+	PublicKey []byte `cbor:"pk"`
+	Nonce     []byte `cbor:"nonce"`
+	Data      []byte `cbor:"data"`
+}
+
+// envelopeFormatSealedBox tags an envelope produced by this package's own
+// Encrypt, as opposed to other envelope formats the runtime SDK may
+// also accept.
+const envelopeFormatSealedBox = 1
+
+// Encrypt encrypts plaintext `data` to the runtime's call data public key,
+// generating a fresh ephemeral key pair for the caller side of the exchange
+// so the runtime can derive the shared secret without the caller ever
+// exposing a long-term key. It is exported so that clients which want to
+// build their own encrypted envelopes (e.g. for an encrypted raw
+// transaction) can do so without going through PrepareCallData's policy
+// gate, which only applies to eth_call/eth_estimateGas.
+func Encrypt(data []byte, key *CallDataPublicKey) ([]byte, error) {
+	callerPub, callerPriv, err := box.GenerateKey(rand.Reader)
+	if err != nil {
+		return nil, fmt.Errorf("generate ephemeral key pair: %w", err)
+	}
+
+	var nonce [24]byte
+	if _, err := rand.Read(nonce[:]); err != nil {
+		return nil, fmt.Errorf("generate nonce: %w", err)
+	}
+
+	runtimePub := key.PublicKey
+	sealed := box.Seal(nil, data, &nonce, &runtimePub, callerPriv)
+
+	envelope, err := cbor.Marshal(sealedEnvelope{
+		Format:    envelopeFormatSealedBox,
+		PublicKey: callerPub[:],
+		Nonce:     nonce[:],
+		Data:      sealed,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("encode envelope: %w", err)
+	}
+	return envelope, nil
+}
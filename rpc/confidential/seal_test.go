@@ -0,0 +1,42 @@
+package confidential
+
+import (
+	"crypto/rand"
+	"testing"
+
+	"github.com/fxamacker/cbor/v2"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/nacl/box"
+)
+
+// TestEncrypt_ProducesRecognizableEnvelope round-trips a plaintext payload
+// through Encrypt and the runtime's own box.Open, proving the envelope is
+// both detected by IsEnvelope and actually decryptable by the holder of the
+// runtime's private key (standing in for the enclave in this unit test).
+func TestEncrypt_ProducesRecognizableEnvelope(t *testing.T) {
+	runtimePub, runtimePriv, err := box.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	key := &CallDataPublicKey{Epoch: 7}
+	copy(key.PublicKey[:], runtimePub[:])
+
+	plaintext := []byte("hello confidential runtime")
+	require.False(t, IsEnvelope(plaintext))
+
+	envelope, err := Encrypt(plaintext, key)
+	require.NoError(t, err)
+	require.True(t, IsEnvelope(envelope), "Encrypt output must be recognized as an envelope")
+
+	var wire sealedEnvelope
+	require.NoError(t, cbor.Unmarshal(envelope, &wire))
+	require.EqualValues(t, envelopeFormatSealedBox, wire.Format)
+
+	var callerPub [32]byte
+	copy(callerPub[:], wire.PublicKey)
+	var nonce [24]byte
+	copy(nonce[:], wire.Nonce)
+
+	opened, ok := box.Open(nil, wire.Data, &nonce, &callerPub, runtimePriv)
+	require.True(t, ok, "runtime must be able to decrypt the envelope")
+	require.Equal(t, plaintext, opened)
+}
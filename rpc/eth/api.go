@@ -0,0 +1,59 @@
+// Package eth implements the "eth" JSON-RPC namespace.
+package eth
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/oasisprotocol/oasis-core/go/common/logging"
+
+	"github.com/starfishlabs/oasis-evm-web3-gateway/rpc/backend"
+)
+
+// PublicAPI implements the eth_* namespace of the JSON-RPC API.
+type PublicAPI struct {
+	backend      backend.Backend
+	logStore     LogStore
+	receiptStore ReceiptStore
+	logger       *logging.Logger
+}
+
+// NewPublicAPI creates a new "eth" namespace API instance.
+func NewPublicAPI(backend backend.Backend, logStore LogStore, receiptStore ReceiptStore, logger *logging.Logger) *PublicAPI {
+	return &PublicAPI{
+		backend:      backend,
+		logStore:     logStore,
+		receiptStore: receiptStore,
+		logger:       logger.With("module", "eth_rpc"),
+	}
+}
+
+// SendRawTransaction relays a raw, signed transaction to the Oasis runtime.
+// Both legacy and EIP-1559 dynamic-fee (type-2) transactions are accepted;
+// the transaction type is preserved all the way through to submission so
+// that its effective tip can later be sampled for eth_feeHistory.
+func (api *PublicAPI) SendRawTransaction(ctx context.Context, raw hexutil.Bytes) (hexutil.Bytes, error) {
+	tx := new(types.Transaction)
+	if err := tx.UnmarshalBinary(raw); err != nil {
+		return nil, fmt.Errorf("decode raw transaction: %w", err)
+	}
+
+	switch tx.Type() {
+	case types.LegacyTxType, types.DynamicFeeTxType:
+	default:
+		return nil, fmt.Errorf("unsupported transaction type %d", tx.Type())
+	}
+
+	return api.submitTransaction(ctx, tx)
+}
+
+func (api *PublicAPI) submitTransaction(ctx context.Context, tx *types.Transaction) (hexutil.Bytes, error) {
+	hash, err := api.backend.SubmitTransaction(ctx, tx)
+	if err != nil {
+		api.logger.Debug("failed to submit transaction", "err", err, "tx_hash", tx.Hash())
+		return nil, err
+	}
+	return hash.Bytes(), nil
+}
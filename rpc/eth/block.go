@@ -0,0 +1,100 @@
+package eth
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rpc"
+
+	"github.com/starfishlabs/oasis-evm-web3-gateway/rpc/backend"
+)
+
+// GetBlockByNumber implements eth_getBlockByNumber, including the block's
+// logs bloom (see blockBloom) so that clients which pre-filter by
+// block.Bloom() before calling eth_getLogs see it populated.
+func (api *PublicAPI) GetBlockByNumber(ctx context.Context, number rpc.BlockNumber, fullTx bool) (map[string]interface{}, error) {
+	resolved, err := api.resolveRPCBlockNumber(ctx, number)
+	if err != nil {
+		return nil, fmt.Errorf("resolve block number: %w", err)
+	}
+
+	blk, err := api.backend.BlockByNumber(ctx, resolved)
+	if err != nil {
+		return nil, fmt.Errorf("get block %d: %w", resolved, err)
+	}
+
+	bloom, err := api.blockBloom(ctx, blk.Number)
+	if err != nil {
+		return nil, err
+	}
+	return marshalBlock(blk, bloom, fullTx), nil
+}
+
+// GetBlockByHash implements eth_getBlockByHash, including the block's logs
+// bloom (see blockBloom).
+func (api *PublicAPI) GetBlockByHash(ctx context.Context, hash common.Hash, fullTx bool) (map[string]interface{}, error) {
+	blk, err := api.backend.BlockByHash(ctx, hash)
+	if err != nil {
+		return nil, fmt.Errorf("get block %s: %w", hash, err)
+	}
+
+	bloom, err := api.blockBloom(ctx, blk.Number)
+	if err != nil {
+		return nil, err
+	}
+	return marshalBlock(blk, bloom, fullTx), nil
+}
+
+// resolveRPCBlockNumber turns an eth_getBlockByNumber selector ("latest",
+// "pending", "earliest", or an explicit number) into a concrete block
+// number.
+func (api *PublicAPI) resolveRPCBlockNumber(ctx context.Context, number rpc.BlockNumber) (uint64, error) {
+	switch number {
+	case rpc.LatestBlockNumber, rpc.PendingBlockNumber:
+		return api.backend.BlockNumber(ctx)
+	case rpc.EarliestBlockNumber:
+		return 0, nil
+	default:
+		if number < 0 {
+			return api.backend.BlockNumber(ctx)
+		}
+		return uint64(number), nil
+	}
+}
+
+// blockBloom returns the logs bloom to embed in an eth_getBlockByNumber /
+// eth_getBlockByHash response for the given block number.
+func (api *PublicAPI) blockBloom(ctx context.Context, number uint64) (types.Bloom, error) {
+	bloom, err := api.backend.BlockBloom(ctx, number)
+	if err != nil {
+		return types.Bloom{}, fmt.Errorf("get block bloom: %w", err)
+	}
+	return types.BytesToBloom(bloom[:]), nil
+}
+
+// marshalBlock renders a backend.Block as the JSON-RPC eth_getBlockByNumber
+// / eth_getBlockByHash response object.
+func marshalBlock(blk *backend.Block, bloom types.Bloom, fullTx bool) map[string]interface{} {
+	txs := make([]interface{}, len(blk.Transactions))
+	for i, hash := range blk.Transactions {
+		if fullTx {
+			txs[i] = map[string]interface{}{"hash": hash}
+		} else {
+			txs[i] = hash
+		}
+	}
+
+	return map[string]interface{}{
+		"number":       hexutil.Uint64(blk.Number),
+		"hash":         blk.Hash,
+		"parentHash":   blk.ParentHash,
+		"timestamp":    hexutil.Uint64(blk.Timestamp),
+		"gasUsed":      hexutil.Uint64(blk.GasUsed),
+		"gasLimit":     hexutil.Uint64(blk.GasLimit),
+		"logsBloom":    bloom,
+		"transactions": txs,
+	}
+}
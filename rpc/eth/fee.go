@@ -0,0 +1,155 @@
+package eth
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"sort"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// maxFeeHistoryBlockCount bounds how many blocks a single eth_feeHistory
+// call is allowed to span, mirroring go-ethereum's own cap.
+const maxFeeHistoryBlockCount = 1024
+
+// defaultTip is suggested when the sampled block has no transactions to
+// derive a priority fee from.
+var defaultTip = big.NewInt(1_000_000_000) // 1 gwei
+
+// FeeHistoryResult is the eth_feeHistory response payload.
+type FeeHistoryResult struct {
+	OldestBlock  *hexutil.Big     `json:"oldestBlock"`
+	BaseFee      []*hexutil.Big   `json:"baseFeePerGas"`
+	GasUsedRatio []float64        `json:"gasUsedRatio"`
+	Reward       [][]*hexutil.Big `json:"reward,omitempty"`
+}
+
+// MaxPriorityFeePerGas suggests a priority fee (the "tip") for a type-2
+// transaction, based on the rewards paid in the most recently indexed block.
+func (api *PublicAPI) MaxPriorityFeePerGas(ctx context.Context) (*hexutil.Big, error) {
+	latest, err := api.backend.BlockNumber(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("get latest block number: %w", err)
+	}
+
+	info, err := api.backend.BlockFeeInfo(ctx, latest)
+	if err != nil {
+		return nil, fmt.Errorf("get block fee info: %w", err)
+	}
+
+	tip := suggestedTip(info.Rewards)
+	return (*hexutil.Big)(tip), nil
+}
+
+// FeeHistory implements eth_feeHistory: it returns the base fee, gas used
+// ratio, and (optionally) reward percentiles for `blockCount` blocks ending
+// at `lastBlock`.
+func (api *PublicAPI) FeeHistory(ctx context.Context, blockCount hexutil.Uint64, lastBlock rpc.BlockNumber, rewardPercentiles []float64) (*FeeHistoryResult, error) {
+	count := uint64(blockCount)
+	if count == 0 {
+		return nil, fmt.Errorf("block count must be greater than 0")
+	}
+	if count > maxFeeHistoryBlockCount {
+		count = maxFeeHistoryBlockCount
+	}
+
+	if err := validatePercentiles(rewardPercentiles); err != nil {
+		return nil, err
+	}
+
+	last, err := api.resolveRPCBlockNumber(ctx, lastBlock)
+	if err != nil {
+		return nil, fmt.Errorf("resolve last block number: %w", err)
+	}
+	if count > last+1 {
+		count = last + 1
+	}
+	oldest := last - count + 1
+
+	res := &FeeHistoryResult{
+		OldestBlock:  (*hexutil.Big)(new(big.Int).SetUint64(oldest)),
+		BaseFee:      make([]*hexutil.Big, 0, count+1),
+		GasUsedRatio: make([]float64, 0, count),
+	}
+	if len(rewardPercentiles) > 0 {
+		res.Reward = make([][]*hexutil.Big, 0, count)
+	}
+
+	var nextBaseFee *big.Int
+	for number := oldest; number <= last; number++ {
+		info, err := api.backend.BlockFeeInfo(ctx, number)
+		if err != nil {
+			return nil, fmt.Errorf("get block fee info for block %d: %w", number, err)
+		}
+
+		res.BaseFee = append(res.BaseFee, (*hexutil.Big)(info.BaseFee))
+		nextBaseFee = info.BaseFee
+		if info.GasLimit > 0 {
+			res.GasUsedRatio = append(res.GasUsedRatio, float64(info.GasUsed)/float64(info.GasLimit))
+		} else {
+			res.GasUsedRatio = append(res.GasUsedRatio, 0)
+		}
+
+		if len(rewardPercentiles) > 0 {
+			res.Reward = append(res.Reward, rewardsAtPercentiles(info.Rewards, rewardPercentiles))
+		}
+	}
+	// eth_feeHistory includes one extra, trailing base fee for the block
+	// that would follow `lastBlock`; since the gateway synthesizes base
+	// fees rather than deriving them from parent usage, repeat the last one.
+	res.BaseFee = append(res.BaseFee, (*hexutil.Big)(nextBaseFee))
+
+	return res, nil
+}
+
+// suggestedTip returns the median of a block's effective priority fees, or
+// a small non-zero default when the block has no transactions to sample.
+func suggestedTip(rewards []*big.Int) *big.Int {
+	if len(rewards) == 0 {
+		return new(big.Int).Set(defaultTip)
+	}
+	sorted := append([]*big.Int(nil), rewards...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Cmp(sorted[j]) < 0 })
+	return sorted[len(sorted)/2]
+}
+
+// validatePercentiles rejects a rewardPercentiles list that is not
+// monotonically increasing or contains a value outside [0, 100], matching
+// go-ethereum's own eth_feeHistory validation.
+func validatePercentiles(percentiles []float64) error {
+	prev := -1.0
+	for _, p := range percentiles {
+		if p < 0 || p > 100 {
+			return fmt.Errorf("invalid reward percentile %f: must be in [0, 100]", p)
+		}
+		if p < prev {
+			return fmt.Errorf("invalid reward percentiles: must be monotonically increasing")
+		}
+		prev = p
+	}
+	return nil
+}
+
+// rewardsAtPercentiles maps requested reward percentiles (0-100) onto the
+// sorted list of effective priority fees paid within a block.
+func rewardsAtPercentiles(rewards []*big.Int, percentiles []float64) []*hexutil.Big {
+	out := make([]*hexutil.Big, len(percentiles))
+	if len(rewards) == 0 {
+		zero := (*hexutil.Big)(big.NewInt(0))
+		for i := range out {
+			out[i] = zero
+		}
+		return out
+	}
+
+	sorted := append([]*big.Int(nil), rewards...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Cmp(sorted[j]) < 0 })
+
+	for i, p := range percentiles {
+		idx := int(p / 100 * float64(len(sorted)-1))
+		out[i] = (*hexutil.Big)(sorted[idx])
+	}
+	return out
+}
@@ -0,0 +1,145 @@
+package eth
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/eth/filters"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// LogStore is the log persistence layer queried once the bloom pre-filter
+// has narrowed a request down to a set of candidate blocks.
+type LogStore interface {
+	GetLogs(ctx context.Context, blockNumbers []uint64, addresses []common.Address, topics [][]common.Hash) ([]*types.Log, error)
+}
+
+// GetLogs implements eth_getLogs. Its parameter is go-ethereum's own
+// filters.FilterCriteria, the same type ethclient.FilterLogs serializes on
+// the wire (hex "fromBlock"/"toBlock" or "latest"/"earliest", "address",
+// "topics", "blockHash"), so this is a drop-in eth_getLogs handler rather
+// than a type only this package's own callers could construct.
+//
+// It pre-filters candidate blocks against their stored logs bloom before
+// hitting the log store, so that ranges which obviously cannot contain a
+// match never reach the database.
+func (api *PublicAPI) GetLogs(ctx context.Context, crit filters.FilterCriteria) ([]*types.Log, error) {
+	blockNumbers, err := api.resolveBlockRange(ctx, crit)
+	if err != nil {
+		return nil, fmt.Errorf("resolve block range: %w", err)
+	}
+
+	var matches []uint64
+	for _, number := range blockNumbers {
+		bloom, err := api.backend.BlockBloom(ctx, number)
+		if err != nil {
+			return nil, fmt.Errorf("get block bloom for block %d: %w", number, err)
+		}
+		if !matchesBloom(types.BytesToBloom(bloom[:]), crit.Addresses, crit.Topics) {
+			continue
+		}
+		matches = append(matches, number)
+	}
+
+	// NOTE: the log store query itself still applies the full address/topic
+	// filter; the bloom check above only narrows which blocks it is run
+	// against.
+	return api.logStore.GetLogs(ctx, matches, crit.Addresses, crit.Topics)
+}
+
+// resolveBlockRange expands a FilterCriteria's block selector (an explicit
+// range, or a single BlockHash) into the concrete list of block numbers to
+// scan.
+func (api *PublicAPI) resolveBlockRange(ctx context.Context, crit filters.FilterCriteria) ([]uint64, error) {
+	if crit.BlockHash != nil {
+		number, err := api.backend.BlockNumberByHash(ctx, *crit.BlockHash)
+		if err != nil {
+			return nil, err
+		}
+		return []uint64{number}, nil
+	}
+
+	latest, err := api.backend.BlockNumber(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	from, err := resolveBlockNumber(crit.FromBlock, latest)
+	if err != nil {
+		return nil, err
+	}
+	to, err := resolveBlockNumber(crit.ToBlock, latest)
+	if err != nil {
+		return nil, err
+	}
+	if from > to {
+		return nil, fmt.Errorf("invalid block range: from %d > to %d", from, to)
+	}
+
+	numbers := make([]uint64, 0, to-from+1)
+	for n := from; n <= to; n++ {
+		numbers = append(numbers, n)
+	}
+	return numbers, nil
+}
+
+// resolveBlockNumber turns a possibly-nil *big.Int block selector (as used
+// by filters.FilterCriteria, where nil/negative values mean "latest") into
+// a concrete block number.
+func resolveBlockNumber(selector *big.Int, latest uint64) (uint64, error) {
+	if selector == nil {
+		return latest, nil
+	}
+
+	switch n := rpc.BlockNumber(selector.Int64()); n {
+	case rpc.LatestBlockNumber, rpc.PendingBlockNumber:
+		return latest, nil
+	case rpc.EarliestBlockNumber:
+		return 0, nil
+	default:
+		if n < 0 {
+			return latest, nil
+		}
+		return uint64(n), nil
+	}
+}
+
+// matchesBloom reports whether a block's logs bloom could possibly contain
+// a log matching the given address and topic filters. An empty filter
+// matches unconditionally, since a block bloom can only rule candidates
+// out, never in.
+func matchesBloom(bloom types.Bloom, addresses []common.Address, topics [][]common.Hash) bool {
+	if len(addresses) > 0 {
+		var found bool
+		for _, addr := range addresses {
+			if types.BloomLookup(bloom, addr) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+
+	for _, alternatives := range topics {
+		if len(alternatives) == 0 {
+			continue
+		}
+		var found bool
+		for _, topic := range alternatives {
+			if types.BloomLookup(bloom, topic) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+
+	return true
+}
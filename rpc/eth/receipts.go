@@ -0,0 +1,56 @@
+package eth
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// ReceiptStore is the subset of the receipt persistence layer that
+// GetBlockReceipts needs. It fetches every receipt belonging to a block in
+// a single query rather than one round trip per transaction.
+type ReceiptStore interface {
+	GetReceiptsByBlockHash(ctx context.Context, blockHash common.Hash) ([]*types.Receipt, error)
+}
+
+// GetBlockReceipts implements eth_getBlockReceipts, returning every
+// transaction receipt for a block in one call.
+func (api *PublicAPI) GetBlockReceipts(ctx context.Context, blockNrOrHash rpc.BlockNumberOrHash) ([]*types.Receipt, error) {
+	hash, err := api.resolveBlockHash(ctx, blockNrOrHash)
+	if err != nil {
+		return nil, fmt.Errorf("resolve block: %w", err)
+	}
+
+	receipts, err := api.receiptStore.GetReceiptsByBlockHash(ctx, hash)
+	if err != nil {
+		return nil, fmt.Errorf("get receipts for block %s: %w", hash, err)
+	}
+	return receipts, nil
+}
+
+// resolveBlockHash turns a block number-or-hash selector into a concrete
+// block hash.
+func (api *PublicAPI) resolveBlockHash(ctx context.Context, blockNrOrHash rpc.BlockNumberOrHash) (common.Hash, error) {
+	if hash, ok := blockNrOrHash.Hash(); ok {
+		return hash, nil
+	}
+
+	number, ok := blockNrOrHash.Number()
+	if !ok {
+		return common.Hash{}, fmt.Errorf("neither block number nor hash specified")
+	}
+
+	resolved := uint64(number)
+	if number < 0 {
+		latest, err := api.backend.BlockNumber(ctx)
+		if err != nil {
+			return common.Hash{}, err
+		}
+		resolved = latest
+	}
+
+	return api.backend.BlockHashByNumber(ctx, resolved)
+}
@@ -0,0 +1,285 @@
+// Package ratelimit enforces the gateway's JSON-RPC rate limit, per-method
+// allow/deny, and namespace toggle policy ahead of method dispatch.
+package ratelimit
+
+import (
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"golang.org/x/time/rate"
+
+	"github.com/starfishlabs/oasis-evm-web3-gateway/conf"
+)
+
+var (
+	acceptedCalls = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "web3_gateway_rpc_calls_accepted_total",
+		Help: "Number of JSON-RPC calls accepted, by method and remote.",
+	}, []string{"method", "remote"})
+
+	rejectedCalls = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "web3_gateway_rpc_calls_rejected_total",
+		Help: "Number of JSON-RPC calls rejected, by method, remote, and reason.",
+	}, []string{"method", "remote", "reason"})
+)
+
+func init() {
+	prometheus.MustRegister(acceptedCalls, rejectedCalls)
+}
+
+// RejectReason identifies why a call was rejected, used both for the
+// returned JSON-RPC error and for the Prometheus reason label.
+type RejectReason string
+
+const (
+	RejectNone             RejectReason = ""
+	RejectRateLimited      RejectReason = "rate_limited"
+	RejectNamespaceOff     RejectReason = "namespace_disabled"
+	RejectMethodDenied     RejectReason = "method_denied"
+	RejectBatchTooLarge    RejectReason = "batch_too_large"
+	RejectResponseTooLarge RejectReason = "response_too_large"
+)
+
+// defaultDisabledNamespaces lists namespaces that are off unless explicitly
+// enabled, since they expose node-internal or debugging functionality.
+var defaultDisabledNamespaces = map[string]bool{
+	"debug": true,
+}
+
+// bucketTTL is how long a per-remote token bucket may sit idle before the
+// janitor reclaims it. Without eviction, a client that varies its apparent
+// remote address (rotating source port, spoofed X-Forwarded-For, ...) would
+// grow `buckets` without bound.
+const bucketTTL = 10 * time.Minute
+
+// janitorInterval is how often the janitor goroutine sweeps for expired
+// buckets.
+const janitorInterval = time.Minute
+
+// bucket pairs a per-remote token bucket with the last time it was used, so
+// the janitor can tell which entries are stale.
+type bucket struct {
+	limiter  *rate.Limiter
+	lastUsed time.Time
+}
+
+// Limiter enforces a gateway's RateLimitConfig and MethodsConfig.
+type Limiter struct {
+	cfg *conf.RateLimitConfig
+	mth *conf.MethodsConfig
+
+	mu      sync.Mutex
+	buckets map[string]*bucket
+	global  *rate.Limiter
+
+	stop chan struct{}
+}
+
+// New creates a Limiter from the given configuration. Either cfg or mth may
+// be nil, in which case the corresponding checks are skipped. Call Close
+// when the gateway shuts down to stop the bucket-eviction janitor.
+func New(cfg *conf.RateLimitConfig, mth *conf.MethodsConfig) *Limiter {
+	l := &Limiter{
+		cfg:     cfg,
+		mth:     mth,
+		buckets: make(map[string]*bucket),
+		stop:    make(chan struct{}),
+	}
+	if cfg != nil && cfg.Enabled && cfg.GlobalQPS > 0 {
+		burst := int(cfg.GlobalQPS)
+		if burst <= 0 {
+			burst = 1
+		}
+		l.global = rate.NewLimiter(rate.Limit(cfg.GlobalQPS), burst)
+	}
+	if cfg != nil && cfg.Enabled && cfg.PerIPQPS > 0 {
+		go l.runJanitor()
+	}
+	return l
+}
+
+// Close stops the bucket-eviction janitor. Safe to call even if the janitor
+// was never started.
+func (l *Limiter) Close() {
+	select {
+	case <-l.stop:
+	default:
+		close(l.stop)
+	}
+}
+
+func (l *Limiter) runJanitor() {
+	ticker := time.NewTicker(janitorInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			l.evictStale()
+		case <-l.stop:
+			return
+		}
+	}
+}
+
+func (l *Limiter) evictStale() {
+	cutoff := time.Now().Add(-bucketTTL)
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	for remote, b := range l.buckets {
+		if b.lastUsed.Before(cutoff) {
+			delete(l.buckets, remote)
+		}
+	}
+}
+
+// Allow reports whether a single JSON-RPC call for `method` from `remote`
+// may proceed, recording a Prometheus counter either way.
+func (l *Limiter) Allow(method, remote string) RejectReason {
+	if reason := l.check(method, remote); reason != RejectNone {
+		rejectedCalls.WithLabelValues(method, remote, string(reason)).Inc()
+		return reason
+	}
+	acceptedCalls.WithLabelValues(method, remote).Inc()
+	return RejectNone
+}
+
+// AllowBatch reports whether a batch of the given size is within the
+// configured maximum, without consuming any rate-limit tokens.
+func (l *Limiter) AllowBatch(size int) RejectReason {
+	if l.cfg == nil || !l.cfg.Enabled || l.cfg.MaxBatchSize == 0 {
+		return RejectNone
+	}
+	if size > l.cfg.MaxBatchSize {
+		return RejectBatchTooLarge
+	}
+	return RejectNone
+}
+
+// AllowResponseSize reports whether a response of the given size is within
+// the configured maximum.
+func (l *Limiter) AllowResponseSize(size int) RejectReason {
+	if l.cfg == nil || !l.cfg.Enabled || l.cfg.MaxResponseSize == 0 {
+		return RejectNone
+	}
+	if size > l.cfg.MaxResponseSize {
+		return RejectResponseTooLarge
+	}
+	return RejectNone
+}
+
+func (l *Limiter) check(method, remote string) RejectReason {
+	if reason := l.checkMethod(method); reason != RejectNone {
+		return reason
+	}
+	return l.checkRate(method, remote)
+}
+
+func (l *Limiter) checkMethod(method string) RejectReason {
+	if l.mth == nil {
+		return RejectNone
+	}
+
+	if namespace, ok := methodNamespace(method); ok {
+		enabled, explicit := l.mth.Namespaces[namespace]
+		switch {
+		case explicit && !enabled:
+			return RejectNamespaceOff
+		case !explicit && defaultDisabledNamespaces[namespace]:
+			return RejectNamespaceOff
+		}
+	}
+
+	if len(l.mth.Allow) > 0 {
+		if !contains(l.mth.Allow, method) {
+			return RejectMethodDenied
+		}
+		return RejectNone
+	}
+	if contains(l.mth.Deny, method) {
+		return RejectMethodDenied
+	}
+	return RejectNone
+}
+
+func (l *Limiter) checkRate(method, remote string) RejectReason {
+	if l.cfg == nil || !l.cfg.Enabled {
+		return RejectNone
+	}
+
+	cost := l.cfg.MethodCosts[method]
+	if cost <= 0 {
+		cost = 1
+	}
+
+	now := time.Now()
+	if l.global != nil && !l.global.AllowN(now, cost) {
+		return RejectRateLimited
+	}
+	if l.cfg.PerIPQPS > 0 && !l.bucketFor(remote).AllowN(now, cost) {
+		return RejectRateLimited
+	}
+	return RejectNone
+}
+
+func (l *Limiter) bucketFor(remote string) *rate.Limiter {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	b, ok := l.buckets[remote]
+	if !ok {
+		burst := l.cfg.PerIPBurst
+		if burst <= 0 {
+			burst = int(l.cfg.PerIPQPS)
+			if burst <= 0 {
+				burst = 1
+			}
+		}
+		b = &bucket{limiter: rate.NewLimiter(rate.Limit(l.cfg.PerIPQPS), burst)}
+		l.buckets[remote] = b
+	}
+	b.lastUsed = time.Now()
+	return b.limiter
+}
+
+func methodNamespace(method string) (string, bool) {
+	idx := strings.Index(method, "_")
+	if idx <= 0 {
+		return "", false
+	}
+	return method[:idx], true
+}
+
+// RPCError is a JSON-RPC 2.0 error object, standard codes per
+// https://www.jsonrpc.org/specification#error_object.
+type RPCError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+// Error renders a RejectReason as the standard JSON-RPC error returned to
+// the client.
+func (r RejectReason) Error() *RPCError {
+	switch r {
+	case RejectRateLimited:
+		return &RPCError{Code: -32005, Message: "rate limit exceeded"}
+	case RejectBatchTooLarge:
+		return &RPCError{Code: -32005, Message: "batch too large"}
+	case RejectResponseTooLarge:
+		return &RPCError{Code: -32005, Message: "response too large"}
+	case RejectNamespaceOff, RejectMethodDenied:
+		return &RPCError{Code: -32601, Message: "method not found"}
+	default:
+		return nil
+	}
+}
+
+func contains(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,123 @@
+package ratelimit
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/starfishlabs/oasis-evm-web3-gateway/conf"
+)
+
+func TestLimiter_AllowDeniedMethod(t *testing.T) {
+	l := New(nil, &conf.MethodsConfig{Deny: []string{"eth_getLogs"}})
+
+	reason := l.Allow("eth_getLogs", "127.0.0.1")
+	require.Equal(t, RejectMethodDenied, reason)
+	require.Equal(t, -32601, reason.Error().Code)
+
+	reason = l.Allow("eth_chainId", "127.0.0.1")
+	require.Equal(t, RejectNone, reason)
+}
+
+func TestLimiter_AllowRateLimited(t *testing.T) {
+	l := New(&conf.RateLimitConfig{Enabled: true, PerIPQPS: 1, PerIPBurst: 1}, nil)
+	defer l.Close()
+
+	require.Equal(t, RejectNone, l.Allow("eth_chainId", "127.0.0.1"))
+	// Second call within the same instant exhausts the burst of 1.
+	reason := l.Allow("eth_chainId", "127.0.0.1")
+	require.Equal(t, RejectRateLimited, reason)
+	require.Equal(t, -32005, reason.Error().Code)
+}
+
+func TestLimiter_EvictsStaleBuckets(t *testing.T) {
+	l := New(&conf.RateLimitConfig{Enabled: true, PerIPQPS: 1, PerIPBurst: 1}, nil)
+	defer l.Close()
+
+	l.Allow("eth_chainId", "10.0.0.1")
+	require.Len(t, l.buckets, 1)
+
+	l.evictStale() // not yet stale.
+	require.Len(t, l.buckets, 1)
+
+	l.buckets["10.0.0.1"].lastUsed = l.buckets["10.0.0.1"].lastUsed.Add(-2 * bucketTTL)
+	l.evictStale()
+	require.Len(t, l.buckets, 0)
+}
+
+// TestHTTPMiddleware_RejectsDeniedMethod proves that a denied call is
+// rejected with the documented JSON-RPC error code before it ever reaches
+// the dispatcher.
+func TestHTTPMiddleware_RejectsDeniedMethod(t *testing.T) {
+	l := New(nil, &conf.MethodsConfig{Deny: []string{"eth_getLogs"}})
+
+	var dispatched bool
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		dispatched = true
+	})
+
+	srv := httptest.NewServer(l.HTTPMiddleware(next))
+	defer srv.Close()
+
+	body := `{"jsonrpc":"2.0","id":1,"method":"eth_getLogs","params":[]}`
+	res, err := http.Post(srv.URL, "application/json", strings.NewReader(body))
+	require.NoError(t, err)
+	defer res.Body.Close()
+
+	var rpcRes struct {
+		Error *RPCError `json:"error"`
+	}
+	require.NoError(t, json.NewDecoder(res.Body).Decode(&rpcRes))
+	require.False(t, dispatched, "denied method must not reach the dispatcher")
+	require.NotNil(t, rpcRes.Error)
+	require.Equal(t, -32601, rpcRes.Error.Code)
+}
+
+// TestHTTPMiddleware_RejectsOversizedResponse proves that a response
+// exceeding MaxResponseSize never reaches the client, even though nothing
+// about the request itself was rejectable.
+func TestHTTPMiddleware_RejectsOversizedResponse(t *testing.T) {
+	l := New(&conf.RateLimitConfig{Enabled: true, MaxResponseSize: 10}, nil)
+
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"jsonrpc":"2.0","id":1,"result":"this response is too large"}`))
+	})
+
+	srv := httptest.NewServer(l.HTTPMiddleware(next))
+	defer srv.Close()
+
+	body := `{"jsonrpc":"2.0","id":1,"method":"eth_getLogs","params":[]}`
+	res, err := http.Post(srv.URL, "application/json", strings.NewReader(body))
+	require.NoError(t, err)
+	defer res.Body.Close()
+
+	var rpcRes struct {
+		Error *RPCError `json:"error"`
+	}
+	require.NoError(t, json.NewDecoder(res.Body).Decode(&rpcRes))
+	require.NotNil(t, rpcRes.Error)
+	require.Equal(t, -32005, rpcRes.Error.Code)
+}
+
+func TestGuardWSResponse_RejectsOversizedResponse(t *testing.T) {
+	l := New(&conf.RateLimitConfig{Enabled: true, MaxResponseSize: 10}, nil)
+
+	require.Nil(t, l.GuardWSResponse(5))
+	rpcErr := l.GuardWSResponse(20)
+	require.NotNil(t, rpcErr)
+	require.Equal(t, -32005, rpcErr.Code)
+}
+
+func TestGuardWS_RejectsRateLimited(t *testing.T) {
+	l := New(&conf.RateLimitConfig{Enabled: true, PerIPQPS: 1, PerIPBurst: 1}, nil)
+	defer l.Close()
+
+	require.Nil(t, l.GuardWS("eth_chainId", "127.0.0.1"))
+	rpcErr := l.GuardWS("eth_chainId", "127.0.0.1")
+	require.NotNil(t, rpcErr)
+	require.Equal(t, -32005, rpcErr.Code)
+}
@@ -0,0 +1,137 @@
+package ratelimit
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net"
+	"net/http"
+)
+
+// rpcCall is the subset of a JSON-RPC request this package needs to enforce
+// policy: which method is being called.
+type rpcCall struct {
+	ID     json.RawMessage `json:"id"`
+	Method string          `json:"method"`
+}
+
+// HTTPMiddleware wraps an HTTP JSON-RPC handler, enforcing the Limiter's
+// batch-size cap, per-method allow/deny and namespace toggles, and rate
+// limit before the request reaches `next`, and its max-response-size cap on
+// the way back out. Batch requests are checked method-by-method; the first
+// rejection short-circuits the whole batch. The response is buffered so its
+// size can be checked before any of it reaches the client, since an
+// oversized `eth_getLogs` response is exactly what this guard exists to
+// stop.
+func (l *Limiter) HTTPMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			http.Error(w, "failed to read request body", http.StatusBadRequest)
+			return
+		}
+		r.Body = io.NopCloser(bytes.NewReader(body))
+
+		calls, id, err := decodeCalls(body)
+		if err != nil {
+			// Malformed JSON is not this middleware's concern; let the
+			// dispatcher produce the standard parse-error response.
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		if reason := l.AllowBatch(len(calls)); reason != RejectNone {
+			writeRejection(w, id, reason)
+			return
+		}
+
+		remote := remoteIP(r)
+		for _, c := range calls {
+			if reason := l.Allow(c.Method, remote); reason != RejectNone {
+				writeRejection(w, c.ID, reason)
+				return
+			}
+		}
+
+		rec := newBufferingResponseWriter()
+		next.ServeHTTP(rec, r)
+
+		if reason := l.AllowResponseSize(rec.body.Len()); reason != RejectNone {
+			writeRejection(w, id, reason)
+			return
+		}
+
+		for k, vv := range rec.Header() {
+			w.Header()[k] = vv
+		}
+		w.WriteHeader(rec.status)
+		_, _ = w.Write(rec.body.Bytes())
+	})
+}
+
+// GuardWS applies the same policy as HTTPMiddleware to a single JSON-RPC
+// call read off a WS connection. Call it once per inbound message, before
+// dispatch; a non-nil error is the JSON-RPC error to send back in place of
+// a dispatched response.
+func (l *Limiter) GuardWS(method, remote string) *RPCError {
+	return l.Allow(method, remote).Error()
+}
+
+// GuardWSResponse applies the Limiter's max-response-size cap to a single
+// JSON-RPC response about to be written to a WS connection. Call it once
+// per outbound message, after marshaling but before writing; a non-nil
+// error is the JSON-RPC error to send back in place of the response.
+func (l *Limiter) GuardWSResponse(size int) *RPCError {
+	return l.AllowResponseSize(size).Error()
+}
+
+// bufferingResponseWriter collects a handler's response in memory so
+// HTTPMiddleware can inspect its size before any of it reaches the client.
+type bufferingResponseWriter struct {
+	header http.Header
+	status int
+	body   bytes.Buffer
+}
+
+func newBufferingResponseWriter() *bufferingResponseWriter {
+	return &bufferingResponseWriter{header: make(http.Header), status: http.StatusOK}
+}
+
+func (rw *bufferingResponseWriter) Header() http.Header { return rw.header }
+
+func (rw *bufferingResponseWriter) Write(b []byte) (int, error) { return rw.body.Write(b) }
+
+func (rw *bufferingResponseWriter) WriteHeader(status int) { rw.status = status }
+
+func decodeCalls(body []byte) (calls []rpcCall, firstID json.RawMessage, err error) {
+	var batch []rpcCall
+	if err := json.Unmarshal(body, &batch); err == nil {
+		if len(batch) > 0 {
+			firstID = batch[0].ID
+		}
+		return batch, firstID, nil
+	}
+
+	var single rpcCall
+	if err := json.Unmarshal(body, &single); err != nil {
+		return nil, nil, err
+	}
+	return []rpcCall{single}, single.ID, nil
+}
+
+func remoteIP(r *http.Request) string {
+	if host, _, err := net.SplitHostPort(r.RemoteAddr); err == nil {
+		return host
+	}
+	return r.RemoteAddr
+}
+
+func writeRejection(w http.ResponseWriter, id json.RawMessage, reason RejectReason) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK) // JSON-RPC errors are carried in the body, not the HTTP status.
+	_ = json.NewEncoder(w).Encode(struct {
+		Version string          `json:"jsonrpc"`
+		ID      json.RawMessage `json:"id,omitempty"`
+		Error   *RPCError       `json:"error"`
+	}{Version: "2.0", ID: id, Error: reason.Error()})
+}
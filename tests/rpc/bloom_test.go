@@ -0,0 +1,70 @@
+package rpc
+
+import (
+	"context"
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	oasisTesting "github.com/oasisprotocol/oasis-sdk/client-sdk/go/testing"
+	"github.com/stretchr/testify/require"
+)
+
+// TestBlockBloom deploys the events contract used in TestEth_GetLogsMultiple,
+// asserts the resulting block carries a non-zero logs bloom, and verifies
+// that bloom-based filtering returns the same results as a naive scan.
+func TestBlockBloom(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), OasisBlockTimeout)
+	defer cancel()
+	ec := localClient()
+
+	code := common.FromHex(strings.TrimSpace(evmEventsTestCompiledHex))
+
+	chainID, err := ec.ChainID(context.Background())
+	require.NoError(t, err, "get chainid")
+
+	nonce, err := ec.NonceAt(context.Background(), oasisTesting.Dave.EthAddress, nil)
+	require.NoError(t, err, "get nonce failed")
+
+	tx := types.NewTx(&types.LegacyTx{
+		Nonce:    nonce,
+		Value:    big.NewInt(0),
+		Gas:      1000000,
+		GasPrice: big.NewInt(2),
+		Data:     code,
+	})
+	signer := types.LatestSignerForChainID(chainID)
+	signature, err := crypto.Sign(signer.Hash(tx).Bytes(), daveKey)
+	require.NoError(t, err, "sign tx")
+
+	signedTx, err := tx.WithSignature(signer, signature)
+	require.NoError(t, err, "pack tx")
+
+	err = ec.SendTransaction(ctx, signedTx)
+	require.NoError(t, err, "send transaction failed")
+
+	receipt, err := waitTransaction(ctx, ec, signedTx.Hash())
+	require.NoError(t, err)
+	require.Equal(t, uint64(1), receipt.Status)
+
+	block, err := ec.BlockByHash(ctx, receipt.BlockHash)
+	require.NoError(t, err)
+	require.NotEqual(t, types.Bloom{}, block.Bloom(), "block bloom should be populated")
+
+	// Naive scan: fetch logs for the whole block by hash.
+	naive, err := ec.FilterLogs(ctx, ethereum.FilterQuery{BlockHash: &receipt.BlockHash})
+	require.NoError(t, err)
+
+	// Bloom-based filter: restrict to the contract address emitted by the deploy.
+	filtered, err := ec.FilterLogs(ctx, ethereum.FilterQuery{
+		BlockHash: &receipt.BlockHash,
+		Addresses: []common.Address{receipt.ContractAddress},
+	})
+	require.NoError(t, err)
+
+	require.Equal(t, naive, filtered, "bloom-filtered logs should match naive scan")
+}
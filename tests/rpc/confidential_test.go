@@ -0,0 +1,87 @@
+package rpc
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+
+	"github.com/starfishlabs/oasis-evm-web3-gateway/rpc/confidential"
+)
+
+// TestOasis_CallDataPublicKey exercises oasis_callDataPublicKey, the RPC
+// clients use to fetch the runtime's confidential call encryption key.
+func TestOasis_CallDataPublicKey(t *testing.T) {
+	rpcRes := call(t, "oasis_callDataPublicKey", []interface{}{})
+
+	var key struct {
+		PublicKey string `json:"public_key"`
+		Epoch     uint64 `json:"epoch"`
+	}
+	require.NoError(t, json.Unmarshal(rpcRes.Result, &key))
+	require.NotEmpty(t, key.PublicKey, "call data public key should be populated")
+}
+
+// TestEth_CallWithEnvelope ensures an already-encrypted Oasis call envelope
+// passed as eth_call data is forwarded to the runtime unchanged rather than
+// being rejected or double-wrapped.
+func TestEth_CallWithEnvelope(t *testing.T) {
+	// A minimal CBOR map carrying the "format" key that marks an Oasis call
+	// envelope, e.g. {"format": 1, "body": h''}.
+	envelope := "0xa266666f726d61740164626f647940"
+
+	param := []interface{}{
+		map[string]interface{}{"data": envelope},
+		"latest",
+	}
+	// The gateway should forward this unchanged rather than attempting to
+	// auto-wrap it; we only assert the call is accepted (no error), since
+	// the contract call itself is expected to fail without a real
+	// confidential runtime behind the test harness.
+	rpcRes := call(t, "eth_call", param)
+	require.NotNil(t, rpcRes)
+}
+
+// TestEth_SendRawTransactionEncrypted fetches the runtime's call data
+// public key, encrypts a plaintext payload to it the same way a
+// confidentiality-aware client would, and submits it as transaction
+// calldata end-to-end via eth_sendRawTransaction. This exercises a
+// client-side encrypted envelope submitted as a raw transaction, as
+// opposed to TestEth_CallWithEnvelope which only exercises
+// an already-encrypted eth_call payload.
+func TestEth_SendRawTransactionEncrypted(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), OasisBlockTimeout)
+	defer cancel()
+
+	rpcRes := call(t, "oasis_callDataPublicKey", []interface{}{})
+	var wireKey struct {
+		PublicKey string `json:"public_key"`
+		Epoch     uint64 `json:"epoch"`
+	}
+	require.NoError(t, json.Unmarshal(rpcRes.Result, &wireKey))
+	require.NotEmpty(t, wireKey.PublicKey)
+
+	rawKey, err := hex.DecodeString(wireKey.PublicKey[2:]) // strip "0x"
+	require.NoError(t, err)
+
+	key := &confidential.CallDataPublicKey{Epoch: wireKey.Epoch}
+	copy(key.PublicKey[:], rawKey)
+
+	plaintext := []byte("confidential payload")
+	envelope, err := confidential.Encrypt(plaintext, key)
+	require.NoError(t, err)
+	require.True(t, confidential.IsEnvelope(envelope), "encrypted payload must round-trip as a recognizable envelope")
+
+	to := common.BytesToAddress(common.FromHex("0x1122334455667788990011223344556677889900"))
+	// The gateway is expected to forward an already-encrypted envelope
+	// unchanged to the runtime; whether the runtime accepts it depends on
+	// a real confidential enclave being present, which this harness does
+	// not provide, so we only assert the submission pipeline itself
+	// accepts and relays the envelope as calldata.
+	receipt := submitTransaction(ctx, t, to, big.NewInt(0), 3000003, big.NewInt(2), envelope)
+	require.NotNil(t, receipt)
+}
@@ -0,0 +1,75 @@
+package rpc
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	oasisTesting "github.com/oasisprotocol/oasis-sdk/client-sdk/go/testing"
+	"github.com/stretchr/testify/require"
+)
+
+// TestEth_FeeHistory exercises eth_feeHistory end-to-end against the local
+// gateway, analogous to TestEth_GasPrice.
+func TestEth_FeeHistory(t *testing.T) {
+	ec := localClient()
+
+	history, err := ec.FeeHistory(context.Background(), 4, nil, []float64{25, 50, 75})
+	require.NoError(t, err, "get feeHistory")
+
+	require.NotEmpty(t, history.BaseFee)
+	require.Len(t, history.BaseFee, len(history.GasUsedRatio)+1)
+}
+
+// TestEth_MaxPriorityFeePerGas exercises eth_maxPriorityFeePerGas.
+func TestEth_MaxPriorityFeePerGas(t *testing.T) {
+	ec := localClient()
+
+	tip, err := ec.SuggestGasTipCap(context.Background())
+	require.NoError(t, err, "get maxPriorityFeePerGas")
+	t.Logf("suggested priority fee: %v", tip)
+}
+
+// TestEth_SendDynamicFeeTransaction submits an EIP-1559 (type-2) transaction
+// and ensures it is accepted and mined, analogous to TestEth_SendRawTransaction.
+func TestEth_SendDynamicFeeTransaction(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), OasisBlockTimeout)
+	defer cancel()
+
+	ec := localClient()
+	chainID, err := ec.ChainID(context.Background())
+	require.NoError(t, err, "get chainid")
+
+	nonce, err := ec.NonceAt(context.Background(), oasisTesting.Dave.EthAddress, nil)
+	require.NoError(t, err, "get nonce failed")
+
+	tipCap, err := ec.SuggestGasTipCap(context.Background())
+	require.NoError(t, err, "get maxPriorityFeePerGas")
+
+	baseFee, err := ec.SuggestGasPrice(context.Background())
+	require.NoError(t, err, "get gasPrice")
+
+	to := common.BytesToAddress(common.FromHex("0x1122334455667788990011223344556677889900"))
+	tx := types.NewTx(&types.DynamicFeeTx{
+		ChainID:   chainID,
+		Nonce:     nonce,
+		To:        &to,
+		Value:     big.NewInt(1),
+		Gas:       22000,
+		GasTipCap: tipCap,
+		GasFeeCap: new(big.Int).Add(baseFee, tipCap),
+	})
+	signer := types.LatestSignerForChainID(chainID)
+	signedTx, err := types.SignTx(tx, signer, daveKey)
+	require.NoError(t, err, "sign tx")
+
+	err = ec.SendTransaction(ctx, signedTx)
+	require.NoError(t, err, "send dynamic-fee transaction failed")
+
+	receipt, err := waitTransaction(ctx, ec, signedTx.Hash())
+	require.NoError(t, err)
+	require.EqualValues(t, types.DynamicFeeTxType, receipt.Type)
+	require.EqualValues(t, 1, receipt.Status)
+}
@@ -0,0 +1,99 @@
+package rpc
+
+import (
+	"context"
+	"encoding/json"
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	oasisTesting "github.com/oasisprotocol/oasis-sdk/client-sdk/go/testing"
+	"github.com/stretchr/testify/require"
+)
+
+// TestEth_GetBlockReceipts submits a contract deploy and a value transfer
+// back-to-back, before waiting on either's receipt, so that both land in
+// the same block; it then asserts eth_getBlockReceipts matches both
+// transactions' per-tx eth_getTransactionReceipt responses element-wise.
+func TestEth_GetBlockReceipts(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), OasisBlockTimeout)
+	defer cancel()
+	ec := localClient()
+
+	chainID, err := ec.ChainID(context.Background())
+	require.NoError(t, err, "get chainid")
+
+	nonce, err := ec.NonceAt(context.Background(), oasisTesting.Dave.EthAddress, nil)
+	require.NoError(t, err, "get nonce failed")
+
+	signer := types.LatestSignerForChainID(chainID)
+
+	// tx1: deploy the events contract used throughout this package's tests.
+	deployCode := common.FromHex(strings.TrimSpace(evmEventsTestCompiledHex))
+	deployTx := types.NewTx(&types.LegacyTx{
+		Nonce:    nonce,
+		Value:    big.NewInt(0),
+		Gas:      1000000,
+		GasPrice: big.NewInt(2),
+		Data:     deployCode,
+	})
+	deploySig, err := crypto.Sign(signer.Hash(deployTx).Bytes(), daveKey)
+	require.NoError(t, err, "sign deploy tx")
+	signedDeployTx, err := deployTx.WithSignature(signer, deploySig)
+	require.NoError(t, err, "pack deploy tx")
+
+	// tx2: a plain value transfer, same sender, next nonce.
+	to := common.BytesToAddress(common.FromHex("0x1122334455667788990011223344556677889900"))
+	transferTx := types.NewTx(&types.LegacyTx{
+		Nonce:    nonce + 1,
+		To:       &to,
+		Value:    big.NewInt(1),
+		Gas:      22000,
+		GasPrice: big.NewInt(2),
+	})
+	transferSig, err := crypto.Sign(signer.Hash(transferTx).Bytes(), daveKey)
+	require.NoError(t, err, "sign transfer tx")
+	signedTransferTx, err := transferTx.WithSignature(signer, transferSig)
+	require.NoError(t, err, "pack transfer tx")
+
+	// Submit both before waiting on either, so they have a chance to land
+	// in the same block.
+	require.NoError(t, ec.SendTransaction(ctx, signedDeployTx), "send deploy tx")
+	require.NoError(t, ec.SendTransaction(ctx, signedTransferTx), "send transfer tx")
+
+	deployReceipt, err := waitTransaction(ctx, ec, signedDeployTx.Hash())
+	require.NoError(t, err)
+	require.EqualValues(t, 1, deployReceipt.Status)
+
+	transferReceipt, err := waitTransaction(ctx, ec, signedTransferTx.Hash())
+	require.NoError(t, err)
+	require.EqualValues(t, 1, transferReceipt.Status)
+
+	require.Equal(t, deployReceipt.BlockHash, transferReceipt.BlockHash, "both transactions should have landed in the same block")
+
+	var blockReceipts []map[string]interface{}
+	rawRsp := call(t, "eth_getBlockReceipts", []interface{}{deployReceipt.BlockHash.Hex()})
+	require.NoError(t, json.Unmarshal(rawRsp.Result, &blockReceipts))
+	require.Len(t, blockReceipts, 2, "eth_getBlockReceipts should return both transactions in the block")
+
+	byHash := make(map[string]map[string]interface{}, len(blockReceipts))
+	for _, r := range blockReceipts {
+		byHash[r["transactionHash"].(string)] = r
+	}
+
+	for _, receipt := range []*types.Receipt{deployReceipt, transferReceipt} {
+		found, ok := byHash[receipt.TxHash.Hex()]
+		require.True(t, ok, "eth_getBlockReceipts should include tx %s", receipt.TxHash.Hex())
+
+		single := make(map[string]interface{})
+		rawSingle := call(t, "eth_getTransactionReceipt", []interface{}{receipt.TxHash.Hex()})
+		require.NoError(t, json.Unmarshal(rawSingle.Result, &single))
+
+		require.Equal(t, single["logsBloom"], found["logsBloom"], "logsBloom should match for tx %s", receipt.TxHash.Hex())
+		require.Equal(t, single["contractAddress"], found["contractAddress"], "contractAddress should match for tx %s", receipt.TxHash.Hex())
+		require.Equal(t, single["cumulativeGasUsed"], found["cumulativeGasUsed"], "cumulativeGasUsed should match for tx %s", receipt.TxHash.Hex())
+	}
+}
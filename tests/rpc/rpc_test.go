@@ -9,6 +9,7 @@ import (
 	"math/big"
 	"net/http"
 	"strings"
+	"sync"
 	"testing"
 	"time"
 
@@ -21,6 +22,7 @@ import (
 	oasisTesting "github.com/oasisprotocol/oasis-sdk/client-sdk/go/testing"
 	"github.com/stretchr/testify/require"
 
+	"github.com/starfishlabs/oasis-evm-web3-gateway/client"
 	"github.com/starfishlabs/oasis-evm-web3-gateway/tests"
 )
 
@@ -49,8 +51,8 @@ func call(t *testing.T, method string, params interface{}) *Response {
 	req.Header.Set("Content-Type", "application/json")
 	require.NoError(t, err)
 
-	client := http.Client{}
-	res, err := client.Do(req)
+	httpClient := http.Client{}
+	res, err := httpClient.Do(req)
 	require.NoError(t, err)
 
 	decoder := json.NewDecoder(res.Body)
@@ -65,31 +67,46 @@ func call(t *testing.T, method string, params interface{}) *Response {
 	return rpcRes
 }
 
-func submitTransaction(ctx context.Context, t *testing.T, to common.Address, amount *big.Int, gasLimit uint64, gasPrice *big.Int, data []byte) *types.Receipt {
-	ec := localClient()
-	chainID, err := ec.ChainID(context.Background())
-	require.NoError(t, err)
+// daveChainIDProviderOnce guards the lazy construction of
+// daveChainIDProvider, so every pipeline built in this package's tests
+// shares a single cached chain id lookup instead of re-querying it (and
+// rebuilding the provider that caches it) on every transaction.
+var (
+	daveChainIDProviderOnce sync.Once
+	daveChainIDProvider     *client.ChainIDProvider
+)
 
-	nonce, err := ec.NonceAt(context.Background(), oasisTesting.Dave.EthAddress, nil)
-	require.Nil(t, err, "get nonce failed")
+// sharedChainIDProvider returns the package-wide ChainIDProvider, creating
+// it from ec the first time it's needed.
+func sharedChainIDProvider(ec *ethclient.Client) *client.ChainIDProvider {
+	daveChainIDProviderOnce.Do(func() {
+		daveChainIDProvider = client.NewChainIDProvider(ec)
+	})
+	return daveChainIDProvider
+}
 
-	// Create transaction
-	tx := types.NewTransaction(
-		nonce,
-		to,
-		amount,
-		gasLimit,
-		gasPrice,
-		data,
+// daveLegacyPipeline is the default modifier chain used to build and sign
+// legacy transactions on Dave's behalf throughout this package's tests. It
+// reuses the package's shared ChainIDProvider rather than
+// client.DefaultLegacyPipeline's own, so the chain id is looked up once for
+// the whole test run instead of once per call.
+func daveLegacyPipeline(ec *ethclient.Client, gasLimit uint64, gasPrice *big.Int) *client.Pipeline {
+	return client.NewPipeline(
+		client.NonceProvider(ec, oasisTesting.Dave.EthAddress),
+		client.GasLimitEstimator(gasLimit),
+		client.GasFeeEstimator(gasPrice),
+		client.ChainIDModifier(sharedChainIDProvider(ec)),
+		client.SignerModifier(daveKey),
 	)
-	signer := types.LatestSignerForChainID(chainID)
-	signature, err := crypto.Sign(signer.Hash(tx).Bytes(), daveKey)
-	require.Nil(t, err, "sign tx")
+}
 
-	signedTx, err := tx.WithSignature(signer, signature)
-	require.Nil(t, err, "pack tx")
+func submitTransaction(ctx context.Context, t *testing.T, to common.Address, amount *big.Int, gasLimit uint64, gasPrice *big.Int, data []byte) *types.Receipt {
+	ec := localClient()
 
-	err = ec.SendTransaction(context.Background(), signedTx)
+	signedTx, err := daveLegacyPipeline(ec, gasLimit, gasPrice).Build(ctx, &to, amount, data)
+	require.NoError(t, err, "build tx")
+
+	err = ec.SendTransaction(ctx, signedTx)
 	require.Nil(t, err, "send transaction failed")
 
 	receipt, err := waitTransaction(ctx, ec, signedTx.Hash())